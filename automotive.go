@@ -0,0 +1,158 @@
+package main
+
+import "math"
+
+// Automotive cable data based on ISO 6722-1 (metric road-vehicle cables)
+// and SAE J1128 (low-voltage primary cable, AWG sizes).
+//
+// Outer diameters are typical maximum values from the standards and are
+// intended for planning purposes. Always confirm against the datasheet of
+// the cable manufacturer.
+
+// InsulationWall represents the insulation wall thickness series of
+// an automotive cable.
+type InsulationWall string
+
+const (
+	WallThick InsulationWall = "thick"
+	WallThin  InsulationWall = "thin"
+)
+
+// ISO 6722 temperature classes with their maximum operating temperatures
+var iso6722TempClasses = map[string]WireType{
+	"a": {
+		Name:                "ISO 6722 Class A",
+		MaxTempCelsius:      85.0,
+		Description:         "Automotive cable, temperature class A (-40°C to 85°C)",
+		InsulationThickness: 0.3,
+		Stranding:           5,
+	},
+	"b": {
+		Name:                "ISO 6722 Class B",
+		MaxTempCelsius:      100.0,
+		Description:         "Automotive cable, temperature class B (-40°C to 100°C)",
+		InsulationThickness: 0.3,
+		Stranding:           5,
+	},
+	"c": {
+		Name:                "ISO 6722 Class C",
+		MaxTempCelsius:      125.0,
+		Description:         "Automotive cable, temperature class C (-40°C to 125°C)",
+		InsulationThickness: 0.3,
+		Stranding:           5,
+	},
+	"d": {
+		Name:                "ISO 6722 Class D",
+		MaxTempCelsius:      150.0,
+		Description:         "Automotive cable, temperature class D (-40°C to 150°C)",
+		InsulationThickness: 0.3,
+		Stranding:           5,
+	},
+	"e": {
+		Name:                "ISO 6722 Class E",
+		MaxTempCelsius:      175.0,
+		Description:         "Automotive cable, temperature class E (-40°C to 175°C)",
+		InsulationThickness: 0.3,
+		Stranding:           5,
+	},
+}
+
+// Insulation wall series of the SAE J1128 wire types
+var saeJ1128Walls = map[string]InsulationWall{
+	"gxl": WallThin,
+	"txl": WallThin,
+	"sxl": WallThick,
+}
+
+// ISO 6722 conductor size with maximum cable outer diameters (mm)
+// for the thick-wall and thin-wall insulation series.
+// A zero diameter means the size is not defined for that series.
+type ISO6722Size struct {
+	Area            float64
+	ThickWallOuterD float64
+	ThinWallOuterD  float64
+}
+
+var iso6722SizeTable = []ISO6722Size{
+	{Area: 0.35, ThickWallOuterD: 1.8, ThinWallOuterD: 1.4},
+	{Area: 0.5, ThickWallOuterD: 2.2, ThinWallOuterD: 1.6},
+	{Area: 0.75, ThickWallOuterD: 2.5, ThinWallOuterD: 1.9},
+	{Area: 1.0, ThickWallOuterD: 2.7, ThinWallOuterD: 2.1},
+	{Area: 1.5, ThickWallOuterD: 3.0, ThinWallOuterD: 2.4},
+	{Area: 2.5, ThickWallOuterD: 3.6, ThinWallOuterD: 3.0},
+	{Area: 4.0, ThickWallOuterD: 4.4, ThinWallOuterD: 3.7},
+	{Area: 6.0, ThickWallOuterD: 5.0, ThinWallOuterD: 4.3},
+	{Area: 10.0, ThickWallOuterD: 6.5, ThinWallOuterD: 6.0},
+	{Area: 16.0, ThickWallOuterD: 8.3, ThinWallOuterD: 7.2},
+	{Area: 25.0, ThickWallOuterD: 10.4, ThinWallOuterD: 8.7},
+	{Area: 35.0, ThickWallOuterD: 11.6, ThinWallOuterD: 10.0},
+	{Area: 50.0, ThickWallOuterD: 13.5, ThinWallOuterD: 12.0},
+	{Area: 70.0, ThickWallOuterD: 16.0, ThinWallOuterD: 14.0},
+	{Area: 95.0, ThickWallOuterD: 18.0, ThinWallOuterD: 16.0},
+	{Area: 120.0, ThickWallOuterD: 19.7, ThinWallOuterD: 17.0},
+}
+
+// ISO 6722 conductor sizes (mm²)
+var iso6722Sizes = iso6722Areas()
+
+// SAE J1128 AWG sizes (22 AWG to 4/0)
+var saeJ1128AWGSizes = awgSubset("22", "4/0")
+
+func iso6722Areas() []float64 {
+	areas := make([]float64, len(iso6722SizeTable))
+	for i, size := range iso6722SizeTable {
+		areas[i] = size.Area
+	}
+	return areas
+}
+
+// Select the AWG sizes from one label to another (inclusive).
+func awgSubset(from, to string) []AWGSize {
+	var subset []AWGSize
+	inRange := false
+	for _, size := range awgSizes {
+		if size.Label == from {
+			inRange = true
+		}
+		if inRange {
+			subset = append(subset, size)
+		}
+		if size.Label == to {
+			break
+		}
+	}
+	return subset
+}
+
+// Look up the maximum outer diameter of an ISO 6722 cable.
+//
+// Returns the outer diameter in mm and true if the size is defined
+// for the given insulation wall series, or 0 and false otherwise.
+func automotiveOuterDiameter(area float64, wall InsulationWall) (float64, bool) {
+	for _, size := range iso6722SizeTable {
+		if math.Abs(size.Area-area) > 1e-9 {
+			continue
+		}
+		diameter := size.ThinWallOuterD
+		if wall == WallThick {
+			diameter = size.ThickWallOuterD
+		}
+		return diameter, diameter > 0
+	}
+	return 0, false
+}
+
+// Look up an automotive wire type by ISO 6722 temperature class (a-e)
+// or SAE J1128 type (gxl/txl/sxl).
+//
+// For SAE J1128 types the insulation wall series is fixed by the type;
+// for ISO 6722 classes the given default wall is returned.
+func lookupAutomotiveWireType(key string, defaultWall InsulationWall) (WireType, InsulationWall, bool) {
+	if wireType, ok := iso6722TempClasses[key]; ok {
+		return wireType, defaultWall, true
+	}
+	if wall, ok := saeJ1128Walls[key]; ok {
+		return wireTypes[key], wall, true
+	}
+	return WireType{}, defaultWall, false
+}
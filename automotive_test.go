@@ -0,0 +1,159 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAutomotiveOuterDiameter(t *testing.T) {
+	tests := []struct {
+		name   string
+		area   float64
+		wall   InsulationWall
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "0.5 mm² thin wall",
+			area:   0.5,
+			wall:   WallThin,
+			want:   1.6,
+			wantOK: true,
+		},
+		{
+			name:   "0.5 mm² thick wall",
+			area:   0.5,
+			wall:   WallThick,
+			want:   2.2,
+			wantOK: true,
+		},
+		{
+			name:   "16 mm² thin wall",
+			area:   16.0,
+			wall:   WallThin,
+			want:   7.2,
+			wantOK: true,
+		},
+		{
+			name:   "size not in ISO 6722 series",
+			area:   185.0,
+			wall:   WallThin,
+			want:   0,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := automotiveOuterDiameter(tt.area, tt.wall)
+			if ok != tt.wantOK {
+				t.Errorf("automotiveOuterDiameter() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("automotiveOuterDiameter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupAutomotiveWireType(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		defaultWall InsulationWall
+		wantName    string
+		wantMaxTemp float64
+		wantWall    InsulationWall
+		wantOK      bool
+	}{
+		{
+			name:        "ISO class A keeps default wall",
+			key:         "a",
+			defaultWall: WallThick,
+			wantName:    "ISO 6722 Class A",
+			wantMaxTemp: 85.0,
+			wantWall:    WallThick,
+			wantOK:      true,
+		},
+		{
+			name:        "ISO class E",
+			key:         "e",
+			defaultWall: WallThin,
+			wantName:    "ISO 6722 Class E",
+			wantMaxTemp: 175.0,
+			wantWall:    WallThin,
+			wantOK:      true,
+		},
+		{
+			name:        "SXL forces thick wall",
+			key:         "sxl",
+			defaultWall: WallThin,
+			wantName:    "SXL",
+			wantMaxTemp: 125.0,
+			wantWall:    WallThick,
+			wantOK:      true,
+		},
+		{
+			name:        "TXL forces thin wall",
+			key:         "txl",
+			defaultWall: WallThick,
+			wantName:    "TXL",
+			wantMaxTemp: 125.0,
+			wantWall:    WallThin,
+			wantOK:      true,
+		},
+		{
+			name:        "unknown key",
+			key:         "thhn",
+			defaultWall: WallThin,
+			wantWall:    WallThin,
+			wantOK:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wireType, wall, ok := lookupAutomotiveWireType(tt.key, tt.defaultWall)
+			if ok != tt.wantOK {
+				t.Fatalf("lookupAutomotiveWireType() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if wall != tt.wantWall {
+				t.Errorf("lookupAutomotiveWireType() wall = %v, want %v", wall, tt.wantWall)
+			}
+			if !ok {
+				return
+			}
+			if wireType.Name != tt.wantName {
+				t.Errorf("lookupAutomotiveWireType() name = %v, want %v", wireType.Name, tt.wantName)
+			}
+			if wireType.MaxTempCelsius != tt.wantMaxTemp {
+				t.Errorf("lookupAutomotiveWireType() max temp = %v, want %v", wireType.MaxTempCelsius, tt.wantMaxTemp)
+			}
+		})
+	}
+}
+
+func TestAutomotiveProfileSizes(t *testing.T) {
+	profile := cableProfiles["automotive"]
+
+	t.Run("small area maps to 0.35 mm²", func(t *testing.T) {
+		size, _ := findClosestSizeIn(profile.MetricSizes, 0.3)
+		if size != 0.35 {
+			t.Errorf("findClosestSizeIn() = %v, want 0.35", size)
+		}
+	})
+
+	t.Run("large area limited to 120 mm²", func(t *testing.T) {
+		size, _ := findClosestSizeIn(profile.MetricSizes, 200.0)
+		if size != 120.0 {
+			t.Errorf("findClosestSizeIn() = %v, want 120", size)
+		}
+	})
+
+	t.Run("small area maps to AWG 22", func(t *testing.T) {
+		label, _, _ := findClosestAWGIn(profile.AWGSizes, 0.3)
+		if label != "22" {
+			t.Errorf("findClosestAWGIn() = %v, want 22", label)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Bill of materials
+//
+// Aggregates the cable lengths of a sized project per material, wire type
+// and standard size, adds a cut-waste allowance and exports the result as
+// CSV or Markdown.
+
+// BOMEntry is one line of a bill of materials.
+type BOMEntry struct {
+	Material    string
+	WireType    string
+	Size        string  // Size label, e.g. "4.00 mm²" or "AWG 12"
+	Area        float64 // Conductor area (mm²)
+	Length      float64 // Total cable length without waste (m)
+	OrderLength float64 // Total cable length including waste allowance (m)
+	Weight      float64 // Conductor mass of the order length (kg)
+}
+
+// Conductor mass of a cable.
+//
+// Formula: m = A × 10⁻⁶ × L × ρ_density
+// Where A is in mm², L in m and ρ_density in kg/m³. Returns kg.
+func conductorMass(area, length float64, material CableMaterial) float64 {
+	return area * 1e-6 * length * material.Density
+}
+
+// Look up an AWG size by label.
+func findAWGSize(label string) (AWGSize, bool) {
+	for _, size := range awgSizes {
+		if size.Label == label {
+			return size, true
+		}
+	}
+	return AWGSize{}, false
+}
+
+// Build a bill of materials from the chosen sizes of a project.
+//
+// The cable length of a circuit is its length times two for round trip,
+// times the number of parallel conductors. With useAWG the circuits are
+// grouped by their AWG size instead of the metric size; parallel circuits
+// use the smallest AWG size covering the share of one conductor. Circuits
+// must have been recalculated.
+func buildBOM(p *Project, wastePercent float64, useAWG bool) ([]BOMEntry, error) {
+	type bomKey struct {
+		material, wireType, size string
+	}
+	entries := map[bomKey]*BOMEntry{}
+	entryMaterials := map[bomKey]CableMaterial{}
+
+	for _, c := range p.Circuits {
+		material, wireType, profile, err := c.resolve()
+		if err != nil {
+			return nil, err
+		}
+		if c.MetricSize == 0 {
+			return nil, fmt.Errorf("circuit %q has not been calculated", c.Name)
+		}
+
+		length := c.Length
+		if c.RoundTrip {
+			length *= 2
+		}
+		if c.Conductors > 1 {
+			length *= float64(c.Conductors)
+		}
+
+		var size string
+		var area float64
+		switch {
+		case useAWG && c.Conductors > 1:
+			label, awgArea := findSmallestAWGIn(profile.AWGSizes, c.RequiredArea/float64(c.Conductors))
+			size = "AWG " + label
+			area = awgArea
+		case useAWG:
+			awg, ok := findAWGSize(c.AWGSize)
+			if !ok {
+				return nil, fmt.Errorf("circuit %q: unknown AWG size %q", c.Name, c.AWGSize)
+			}
+			size = "AWG " + awg.Label
+			area = awg.Area
+		default:
+			size = fmt.Sprintf("%.2f mm²", c.MetricSize)
+			area = c.MetricSize
+		}
+
+		key := bomKey{material.Name, wireType.Name, size}
+		entry, ok := entries[key]
+		if !ok {
+			entry = &BOMEntry{Material: material.Name, WireType: wireType.Name, Size: size, Area: area}
+			entries[key] = entry
+			entryMaterials[key] = material
+		}
+		entry.Length += length
+	}
+
+	bom := make([]BOMEntry, 0, len(entries))
+	for key, entry := range entries {
+		entry.OrderLength = entry.Length * (1 + wastePercent/100)
+		entry.Weight = conductorMass(entry.Area, entry.OrderLength, entryMaterials[key])
+		bom = append(bom, *entry)
+	}
+
+	sort.Slice(bom, func(i, j int) bool {
+		if bom[i].Material != bom[j].Material {
+			return bom[i].Material < bom[j].Material
+		}
+		if bom[i].WireType != bom[j].WireType {
+			return bom[i].WireType < bom[j].WireType
+		}
+		return bom[i].Area < bom[j].Area
+	})
+
+	return bom, nil
+}
+
+// Write a bill of materials as CSV.
+func writeBOMCSV(w io.Writer, bom []BOMEntry, withWeight bool) error {
+	cw := csv.NewWriter(w)
+	header := []string{"material", "wire_type", "size", "area_mm2", "length_m", "order_length_m"}
+	if withWeight {
+		header = append(header, "conductor_weight_kg")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	format := func(v float64, decimals int) string {
+		return strconv.FormatFloat(v, 'f', decimals, 64)
+	}
+	for _, e := range bom {
+		record := []string{e.Material, e.WireType, e.Size, format(e.Area, 3), format(e.Length, 2), format(e.OrderLength, 2)}
+		if withWeight {
+			record = append(record, format(e.Weight, 3))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Write a bill of materials as a Markdown table.
+func writeBOMMarkdown(w io.Writer, bom []BOMEntry, withWeight bool, wastePercent float64) {
+	if withWeight {
+		fmt.Fprintln(w, "| Material | Wire Type | Size | Length (m) | Order Length (m) | Conductor Weight (kg) |")
+		fmt.Fprintln(w, "|----------|-----------|------|-----------:|-----------------:|----------------------:|")
+	} else {
+		fmt.Fprintln(w, "| Material | Wire Type | Size | Length (m) | Order Length (m) |")
+		fmt.Fprintln(w, "|----------|-----------|------|-----------:|-----------------:|")
+	}
+
+	var totalWeight float64
+	for _, e := range bom {
+		if withWeight {
+			fmt.Fprintf(w, "| %s | %s | %s | %.2f | %.2f | %.3f |\n", e.Material, e.WireType, e.Size, e.Length, e.OrderLength, e.Weight)
+		} else {
+			fmt.Fprintf(w, "| %s | %s | %s | %.2f | %.2f |\n", e.Material, e.WireType, e.Size, e.Length, e.OrderLength)
+		}
+		totalWeight += e.Weight
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Order lengths include a %.1f%% cut-waste allowance.\n", wastePercent)
+	if withWeight {
+		fmt.Fprintf(w, "Total conductor weight: %.3f kg\n", totalWeight)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func testBOMProject(t *testing.T) *Project {
+	t.Helper()
+	lights := testCircuit("lights") // 6 mm², 5 m round trip
+	fridge := testCircuit("fridge") // 6 mm², 5 m round trip
+	pump := testCircuit("pump")
+	pump.RoundTrip = false
+	pump.Current = 20 // 4.86 mm² one-way, rounds up to 6 mm²
+	heater := testCircuit("heater")
+	heater.Material = "aluminum"
+
+	p := &Project{Name: "Test", Circuits: []Circuit{lights, fridge, pump, heater}}
+	if err := p.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	return p
+}
+
+func TestBuildBOM(t *testing.T) {
+	p := testBOMProject(t)
+
+	bom, err := buildBOM(p, 10, false)
+	if err != nil {
+		t.Fatalf("buildBOM() error = %v", err)
+	}
+	if len(bom) != 2 {
+		t.Fatalf("buildBOM() returned %d entries, want 2: %+v", len(bom), bom)
+	}
+
+	// Aluminum sorts before copper
+	aluminum, copper := bom[0], bom[1]
+	if aluminum.Material != "Aluminum" || copper.Material != "Copper" {
+		t.Fatalf("unexpected order: %+v", bom)
+	}
+
+	// Copper: 2 × 10 m round trip + 5 m one-way
+	if math.Abs(copper.Length-25.0) > 0.0001 {
+		t.Errorf("copper length = %v, want 25", copper.Length)
+	}
+	if math.Abs(copper.OrderLength-27.5) > 0.0001 {
+		t.Errorf("copper order length = %v, want 27.5", copper.OrderLength)
+	}
+	wantWeight := copper.Area * 1e-6 * 27.5 * copperDensity
+	if math.Abs(copper.Weight-wantWeight) > 0.0001 {
+		t.Errorf("copper weight = %v, want %v", copper.Weight, wantWeight)
+	}
+
+	t.Run("uncalculated circuit", func(t *testing.T) {
+		p := &Project{Circuits: []Circuit{testCircuit("new")}}
+		if _, err := buildBOM(p, 0, false); err == nil {
+			t.Errorf("buildBOM() should fail for uncalculated circuits")
+		}
+	})
+
+	t.Run("parallel conductors", func(t *testing.T) {
+		// 292 mm² required: 2 × 150 mm² metric, 2 × 300 kcmil AWG
+		feeder := testCircuit("feeder")
+		feeder.Current = 200
+		feeder.Length = 30
+		feeder.RoundTrip = false
+		p := &Project{Circuits: []Circuit{feeder}}
+		if err := p.recalculate(); err != nil {
+			t.Fatalf("recalculate() error = %v", err)
+		}
+		if p.Circuits[0].Conductors != 2 {
+			t.Fatalf("Conductors = %d, want 2", p.Circuits[0].Conductors)
+		}
+		for useAWG, wantSize := range map[bool]string{false: "150.00 mm²", true: "AWG 300 kcmil"} {
+			bom, err := buildBOM(p, 0, useAWG)
+			if err != nil {
+				t.Fatalf("buildBOM(awg=%v) error = %v", useAWG, err)
+			}
+			if len(bom) != 1 || bom[0].Size != wantSize || math.Abs(bom[0].Length-60) > 0.0001 {
+				t.Errorf("buildBOM(awg=%v) = %+v, want 60 m of %s", useAWG, bom, wantSize)
+			}
+		}
+	})
+
+	t.Run("grouped by AWG", func(t *testing.T) {
+		bom, err := buildBOM(p, 0, true)
+		if err != nil {
+			t.Fatalf("buildBOM() error = %v", err)
+		}
+		for _, e := range bom {
+			if !strings.HasPrefix(e.Size, "AWG ") {
+				t.Errorf("size = %q, want AWG size", e.Size)
+			}
+			if e.OrderLength != e.Length {
+				t.Errorf("order length %v should equal length %v without waste", e.OrderLength, e.Length)
+			}
+		}
+	})
+}
+
+func TestConductorMass(t *testing.T) {
+	// 1 m of 1 mm² copper: 1e-6 m² × 1 m × 8960 kg/m³ = 8.96 g
+	got := conductorMass(1.0, 1.0, materials["copper"])
+	if math.Abs(got-0.00896) > 1e-9 {
+		t.Errorf("conductorMass() = %v, want 0.00896", got)
+	}
+}
+
+func TestWriteBOM(t *testing.T) {
+	bom := []BOMEntry{{Material: "Copper", WireType: "PVC", Size: "4.00 mm²", Area: 4, Length: 10, OrderLength: 11, Weight: 0.394}}
+
+	var csvOut bytes.Buffer
+	if err := writeBOMCSV(&csvOut, bom, true); err != nil {
+		t.Fatalf("writeBOMCSV() error = %v", err)
+	}
+	wantCSV := "material,wire_type,size,area_mm2,length_m,order_length_m,conductor_weight_kg\nCopper,PVC,4.00 mm²,4.000,10.00,11.00,0.394\n"
+	if csvOut.String() != wantCSV {
+		t.Errorf("writeBOMCSV() = %q, want %q", csvOut.String(), wantCSV)
+	}
+
+	var md bytes.Buffer
+	writeBOMMarkdown(&md, bom, false, 10)
+	if !strings.Contains(md.String(), "| Copper | PVC | 4.00 mm² | 10.00 | 11.00 |") {
+		t.Errorf("writeBOMMarkdown() missing row:\n%s", md.String())
+	}
+	if strings.Contains(md.String(), "Weight") {
+		t.Errorf("writeBOMMarkdown() should not include weight:\n%s", md.String())
+	}
+}
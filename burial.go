@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Buried cables
+//
+// Cables laid directly in the ground or in ducts in the ground are cooled
+// by conduction through the soil instead of by the air. Their current
+// rating depends on the soil thermal resistivity, the burial depth and the
+// ground temperature, which takes the place of the ambient temperature.
+// The soil and depth ratings are corrected relative to the reference conditions of
+// IEC 60364-5-52: 2.5 K·m/W, 0.7 m and 20°C.
+
+const (
+	referenceSoilResistivity = 2.5 // K·m/W
+	referenceBurialDepth     = 0.7 // m
+)
+
+// Correction factors for soil thermal resistivities other than 2.5 K·m/W
+// (IEC 60364-5-52, table B.52.16)
+var soilResistivityFactors = []struct {
+	Resistivity float64 // K·m/W
+	Direct      float64 // Cables directly in the ground
+	Duct        float64 // Cables in ducts in the ground
+}{
+	{0.5, 1.88, 1.28}, {0.7, 1.62, 1.20}, {1.0, 1.50, 1.18}, {1.5, 1.28, 1.10},
+	{2.0, 1.12, 1.05}, {2.5, 1.00, 1.00}, {3.0, 0.90, 0.96},
+}
+
+// Correction factors for burial depths other than 0.7 m (IEC 60502-2,
+// normalized to the 0.7 m reference depth)
+var burialDepthFactors = []struct {
+	Depth  float64 // m
+	Factor float64
+}{
+	{0.5, 1.03}, {0.6, 1.01}, {0.7, 1.00}, {0.8, 0.99}, {1.0, 0.97}, {1.25, 0.95},
+	{1.5, 0.94}, {1.75, 0.93}, {2.0, 0.92}, {2.5, 0.90}, {3.0, 0.89},
+}
+
+// Check whether an installation method is in the ground.
+func isBuried(installation InstallationMethod) bool {
+	return installation == InstallationBuried || installation == InstallationBuriedDuct
+}
+
+// Look up the soil thermal resistivity correction factor.
+//
+// Resistivities between table entries use the next higher (worse) entry;
+// resistivities below 0.5 K·m/W use the factor for 0.5 K·m/W.
+func soilResistivityFactor(resistivity float64, installation InstallationMethod) (float64, error) {
+	for _, s := range soilResistivityFactors {
+		if resistivity <= s.Resistivity+1e-9 {
+			if installation == InstallationBuriedDuct {
+				return s.Duct, nil
+			}
+			return s.Direct, nil
+		}
+	}
+	return 0, fmt.Errorf("soil thermal resistivity must be at most %.1f K·m/W", soilResistivityFactors[len(soilResistivityFactors)-1].Resistivity)
+}
+
+// Look up the burial depth correction factor.
+//
+// Depths between table entries use the next deeper entry; depths
+// shallower than 0.5 m use the factor for 0.5 m.
+func burialDepthFactor(depth float64) (float64, error) {
+	if depth <= 0 {
+		return 0, fmt.Errorf("burial depth must be positive")
+	}
+	for _, d := range burialDepthFactors {
+		if depth <= d.Depth+1e-9 {
+			return d.Factor, nil
+		}
+	}
+	return 0, fmt.Errorf("burial depth must be at most %.1f m", burialDepthFactors[len(burialDepthFactors)-1].Depth)
+}
+
+// Check the ground temperature of a buried installation against the wire
+// type's maximum temperature.
+//
+// The ground temperature enters the calculation only as the base
+// temperature in place of the ambient. This already covers the ground
+// temperature factor of IEC 60364-5-52 table B.52.15, so no rating factor
+// is applied for it. Installations above ground are not checked.
+func checkGroundTemp(installation InstallationMethod, groundTempCelsius, maxTempCelsius float64) error {
+	if isBuried(installation) && groundTempCelsius >= maxTempCelsius {
+		return fmt.Errorf("ground temperature must be below the wire type's maximum of %.0f°C", maxTempCelsius)
+	}
+	return nil
+}
+
+// Calculate the combined soil correction factor of a buried installation
+// (soil thermal resistivity × burial depth).
+//
+// A resistivity or depth of 0 uses the reference value. Installations
+// above ground return 1.
+func soilCorrectionFactor(installation InstallationMethod, resistivity, depth float64) (float64, error) {
+	if !isBuried(installation) {
+		return 1, nil
+	}
+	if resistivity == 0 {
+		resistivity = referenceSoilResistivity
+	}
+	if depth == 0 {
+		depth = referenceBurialDepth
+	}
+	if resistivity < 0 {
+		return 0, fmt.Errorf("soil thermal resistivity must be positive")
+	}
+	kSoil, err := soilResistivityFactor(resistivity, installation)
+	if err != nil {
+		return 0, err
+	}
+	kDepth, err := burialDepthFactor(depth)
+	if err != nil {
+		return 0, err
+	}
+	return kSoil * kDepth, nil
+}
+
+// Calculate the temperature rise added by non-reference soil conditions on
+// top of calculateEffectiveTemp.
+//
+// The temperature rise of a conductor grows with the square of the
+// current, so a current rating corrected by k corresponds to a rise
+// scaled by 1/k² at the same current:
+//
+// Formula: ΔT_soil = ΔT_installation × (1/k² - 1)
+//
+// Negative for soil better than the reference.
+func soilTempRise(installation InstallationMethod, k float64) float64 {
+	if k <= 0 {
+		return math.Inf(1)
+	}
+	return installationTempAdjustments[installation] * (1/(k*k) - 1)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSoilCorrectionFactor(t *testing.T) {
+	tests := []struct {
+		installation InstallationMethod
+		resistivity  float64
+		depth        float64
+		want         float64
+	}{
+		{InstallationInAir, 1.0, 1.0, 1.00},
+		{InstallationBuried, 0, 0, 1.00},
+		{InstallationBuried, 2.5, 0.7, 1.00},
+		{InstallationBuried, 1.0, 0.7, 1.50},
+		{InstallationBuriedDuct, 1.0, 0.7, 1.18},
+		{InstallationBuried, 1.2, 0.7, 1.28}, // Next higher resistivity
+		{InstallationBuried, 2.5, 0.9, 0.97}, // Next deeper entry
+		{InstallationBuried, 0.3, 0.3, 1.88 * 1.03},
+		{InstallationBuried, 3.0, 1.0, 0.90 * 0.97},
+	}
+	for _, tt := range tests {
+		got, err := soilCorrectionFactor(tt.installation, tt.resistivity, tt.depth)
+		if err != nil {
+			t.Errorf("%s, %.1f K·m/W, %.1f m: %v", tt.installation, tt.resistivity, tt.depth, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s, %.1f K·m/W, %.1f m: got %.4f, want %.4f", tt.installation, tt.resistivity, tt.depth, got, tt.want)
+		}
+	}
+
+	for _, bad := range [][2]float64{{4, 0.7}, {-1, 0.7}, {2.5, 5}, {2.5, -0.5}} {
+		if _, err := soilCorrectionFactor(InstallationBuried, bad[0], bad[1]); err == nil {
+			t.Errorf("%.1f K·m/W, %.1f m: expected an error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestCheckGroundTemp(t *testing.T) {
+	if err := checkGroundTemp(InstallationBuried, 30, 70); err != nil {
+		t.Errorf("ground below the rating: %v", err)
+	}
+	if err := checkGroundTemp(InstallationBuriedDuct, 70, 70); err == nil {
+		t.Error("expected an error for ground at the rating")
+	}
+	if err := checkGroundTemp(InstallationInAir, 80, 70); err != nil {
+		t.Errorf("above ground: %v", err)
+	}
+}
+
+func TestSoilTempRise(t *testing.T) {
+	if got := soilTempRise(InstallationBuried, 1); got != 0 {
+		t.Errorf("reference soil: got %v, want 0", got)
+	}
+	// 10 K × (1/0.9² - 1) ≈ 2.35 K
+	if got := soilTempRise(InstallationBuried, 0.9); math.Abs(got-2.35) > 0.01 {
+		t.Errorf("k = 0.9: got %.2f K, want about 2.35 K", got)
+	}
+	if got := soilTempRise(InstallationBuried, 1.5); got >= 0 {
+		t.Errorf("good soil: got %.2f K, want a negative rise", got)
+	}
+}
+
+func TestCircuitBuried(t *testing.T) {
+	c := defaultCircuit()
+	c.Name = "array"
+	c.Voltage = 48
+	c.Current = 20
+	c.Length = 40
+	c.Installation = InstallationBuried
+	c.AmbientTempCelsius = 30
+	groundTemp := 20.0
+	c.GroundTempCelsius = &groundTemp
+
+	if got := c.effectiveTemp(); got != 30 {
+		t.Errorf("reference soil: got %.2f°C, want 30°C", got)
+	}
+
+	// Altitude and sunlight do not reach buried cables
+	c.AltitudeMeters = 3000
+	c.SolarIrradiance = 1000
+	if got := c.effectiveTemp(); got != 30 {
+		t.Errorf("buried with altitude and sun: got %.2f°C, want 30°C", got)
+	}
+
+	c.SoilResistivity = 3.0
+	c.BurialDepth = 1.0
+	want := 20 + 10/(0.873*0.873)
+	if got := c.effectiveTemp(); math.Abs(got-want) > 1e-6 {
+		t.Errorf("dry soil: got %.2f°C, want %.2f°C", got, want)
+	}
+	if err := c.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	dryArea := c.RequiredArea
+
+	// Warm ground raises the base temperature only, the soil rise is unchanged
+	groundTemp = 40
+	want = 40 + 10/(0.873*0.873)
+	if got := c.effectiveTemp(); math.Abs(got-want) > 1e-6 {
+		t.Errorf("warm ground: got %.2f°C, want %.2f°C", got, want)
+	}
+	if err := c.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	if c.RequiredArea <= dryArea {
+		t.Errorf("warm ground: required area %.3f mm², want more than %.3f mm²", c.RequiredArea, dryArea)
+	}
+	groundTemp = 90
+	if err := c.validate(); err == nil {
+		t.Error("expected an error for ground at the wire type's maximum temperature")
+	}
+	groundTemp = 20
+
+	c.SoilResistivity = 10
+	if err := c.validate(); err == nil {
+		t.Error("expected an error for soil resistivity out of range")
+	}
+}
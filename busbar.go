@@ -0,0 +1,206 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Busbar sizing
+//
+// Flat rectangular bars, e.g. between battery cells. Resistance follows
+// from the cross-section like for round cables; ampacity and temperature
+// rise use the empirical formula of the Copper Development Association
+// for bars in free air.
+
+// BusbarSize is a rectangular busbar cross-section.
+type BusbarSize struct {
+	Width     float64 // mm
+	Thickness float64 // mm
+}
+
+// Cross-sectional area (mm²).
+func (b BusbarSize) Area() float64 {
+	return b.Width * b.Thickness
+}
+
+// Perimeter of the cross-section (mm).
+func (b BusbarSize) Perimeter() float64 {
+	return 2 * (b.Width + b.Thickness)
+}
+
+func (b BusbarSize) String() string {
+	return fmt.Sprintf("%g × %g mm", b.Width, b.Thickness)
+}
+
+// Standard flat busbar dimensions (width × thickness, mm), by area
+var standardBusbarSizes = sortedBusbarSizes([]BusbarSize{
+	{12, 2}, {15, 2}, {15, 3}, {20, 2}, {20, 3}, {25, 3}, {20, 5}, {30, 3},
+	{25, 5}, {40, 3}, {30, 5}, {40, 5}, {50, 5}, {40, 10}, {60, 5}, {50, 10},
+	{80, 5}, {60, 10}, {100, 5}, {80, 10}, {100, 10},
+})
+
+// Empirical ampacity coefficient of the CDA busbar formula
+const busbarAmpacityCoefficient = 24.9
+
+// Sort busbar sizes by area, then by width.
+func sortedBusbarSizes(sizes []BusbarSize) []BusbarSize {
+	sort.SliceStable(sizes, func(i, j int) bool {
+		if sizes[i].Area() != sizes[j].Area() {
+			return sizes[i].Area() < sizes[j].Area()
+		}
+		return sizes[i].Width < sizes[j].Width
+	})
+	return sizes
+}
+
+// Resistance of a busbar (Ω).
+//
+// Formula: R = ρ(T) × L / A
+func busbarResistance(b BusbarSize, length float64, material CableMaterial, tempCelsius float64) float64 {
+	return calculateResistivityAtTemp(material, tempCelsius) * length / b.Area()
+}
+
+// Calculate the ampacity of a busbar in free air for a temperature rise.
+//
+// Formula (CDA, bar on edge in still air):
+//
+//	I = 24.9 × ΔT^0.61 × A^0.5 × p^0.39 / √ρ(T)
+//
+// Where A is in cm², p in cm, ρ in μΩ·cm at the bar temperature
+// T = T_ambient + ΔT.
+func busbarAmpacity(b BusbarSize, material CableMaterial, ambientTempCelsius, rise float64) float64 {
+	resistivity := calculateResistivityAtTemp(material, ambientTempCelsius+rise) * 100 // Ω·mm²/m to μΩ·cm
+	return busbarAmpacityCoefficient * math.Pow(rise, 0.61) * math.Sqrt(b.Area()/100) * math.Pow(b.Perimeter()/10, 0.39) / math.Sqrt(resistivity)
+}
+
+// Calculate the temperature rise of a busbar carrying a current (K).
+//
+// Inverts busbarAmpacity by bisection. Returns +Inf if the rise would
+// exceed 1000 K.
+func busbarTempRise(b BusbarSize, current float64, material CableMaterial, ambientTempCelsius float64) float64 {
+	low, high := 0.0, 1000.0
+	if busbarAmpacity(b, material, ambientTempCelsius, high) < current {
+		return math.Inf(1)
+	}
+	for i := 0; i < 60; i++ {
+		mid := (low + high) / 2
+		if busbarAmpacity(b, material, ambientTempCelsius, mid) < current {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return high
+}
+
+// Voltage drop of a circuit run as a busbar (%), including its series
+// components.
+//
+// The resistance is taken at the bar temperature, the corrected ambient
+// temperature plus the bar's own temperature rise at the circuit current.
+func busbarDropPercent(c Circuit, b BusbarSize, material CableMaterial) float64 {
+	ambient := c.correctedAmbient()
+	rise := busbarTempRise(b, c.Current, material, ambient)
+	distanceFactor := 1.0
+	if c.RoundTrip {
+		distanceFactor = 2.0
+	}
+	resistance := busbarResistance(b, c.Length*distanceFactor, material, ambient+rise)
+	componentResistance, _ := componentsResistance(c.Components)
+	return c.Current * (resistance + componentResistance) / c.Voltage * 100
+}
+
+// Recommend a standard busbar for a circuit and a temperature rise.
+//
+// Starts with the smallest busbar of at least the area required for the
+// voltage drop and steps up to larger busbars until the ampacity at the
+// maximum temperature rise covers the current and the drop at the bar
+// temperature stays within the circuit's maximum. Returns false if no
+// standard busbar is sufficient.
+func recommendBusbar(sizes []BusbarSize, c Circuit, requiredArea, maxRise float64) (BusbarSize, bool) {
+	material, _, _, _ := c.resolve()
+	maxDrop, _ := c.maxDropPercent()
+	for _, size := range sizes {
+		if size.Area() < requiredArea {
+			continue
+		}
+		if busbarAmpacity(size, material, c.correctedAmbient(), maxRise) >= c.Current && busbarDropPercent(c, size, material) <= maxDrop+1e-9 {
+			return size, true
+		}
+	}
+	return BusbarSize{}, false
+}
+
+// Run the busbar command.
+func runBusbarCommand(args []string) int {
+	fs := flag.NewFlagSet("busbar", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "busbar"
+	bindCircuitFlags(fs, &c)
+	width := fs.Float64("width", 0, "busbar width (mm), with -thickness: check this busbar")
+	thickness := fs.Float64("thickness", 0, "busbar thickness (mm)")
+	maxRise := fs.Float64("max-rise", 30, "maximum temperature rise (K)")
+	diagOpts := bindDiagnosticFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if (*width > 0) != (*thickness > 0) || *width < 0 || *thickness < 0 {
+		fmt.Println("Error: Give both -width and -thickness as positive values.")
+		return exitUsage
+	}
+	if *maxRise <= 0 {
+		fmt.Println("Error: Maximum temperature rise must be positive.")
+		return exitUsage
+	}
+	if err := c.validate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	// Drop left for the busbar after its series components
+	dropPercent, err := c.conductorDropPercent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	material, _, _, _ := c.resolve()
+
+	requiredArea := calculateCableArea(c.Voltage, c.Current, c.Length, dropPercent, material, c.RoundTrip, c.correctedAmbient(), c.Installation)
+
+	fmt.Println("=== Busbar Sizing ===")
+	fmt.Printf("Required Cross-Sectional Area: %.2f mm² (%.2f%% drop)\n", requiredArea, dropPercent)
+
+	bar := BusbarSize{Width: *width, Thickness: *thickness}
+	if bar.Area() == 0 {
+		var ok bool
+		if bar, ok = recommendBusbar(standardBusbarSizes, c, requiredArea, *maxRise); !ok {
+			// Without a busbar there is no result, so this fails without -strict too
+			if code := diagOpts.report(collectDiagnostics(busbarOutOfRangeDiagnostic(c.Current, *maxRise))); code != exitOK {
+				return code
+			}
+			return exitError
+		}
+		fmt.Printf("Recommended Busbar: %s (%.0f mm²)\n", bar, bar.Area())
+	} else {
+		fmt.Printf("Busbar: %s (%.0f mm²)\n", bar, bar.Area())
+	}
+	fmt.Println()
+
+	ambient := c.correctedAmbient()
+	rise := busbarTempRise(bar, c.Current, material, ambient)
+	barTemp := ambient + rise
+	distanceFactor := 1.0
+	if c.RoundTrip {
+		distanceFactor = 2.0
+	}
+	resistance := busbarResistance(bar, c.Length*distanceFactor, material, barTemp)
+	actualDrop := busbarDropPercent(c, bar, material)
+	maxDrop, _ := c.maxDropPercent()
+
+	fmt.Printf("Ampacity (%s, %.0f K rise): %.1f A\n", material.Name, *maxRise, busbarAmpacity(bar, material, ambient, *maxRise))
+	fmt.Printf("Temperature Rise at %.2f A: %.1f K (%.1f°C)\n", c.Current, rise, barTemp)
+	fmt.Printf("Resistance: %.4f mΩ\n", resistance*1000)
+	fmt.Printf("Voltage Drop: %.3f V (%.2f%%)\n", actualDrop/100*c.Voltage, actualDrop)
+	return diagOpts.report(collectDiagnostics(checkBusbarRise(rise, *maxRise), checkVoltageDrop(bar.String(), actualDrop, maxDrop)))
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBusbarAmpacity(t *testing.T) {
+	copper := materials["copper"]
+	bar := BusbarSize{Width: 20, Thickness: 5}
+
+	// 24.9 × 30^0.61 × 1^0.5 × 5^0.39 / √1.956 ≈ 265.6 A
+	if got := busbarAmpacity(bar, copper, 20, 30); math.Abs(got-265.6) > 0.5 {
+		t.Errorf("ampacity 20 × 5 mm = %.1f A, want about 265.6 A", got)
+	}
+
+	aluminum := materials["aluminum"]
+	if cu, al := busbarAmpacity(bar, copper, 20, 30), busbarAmpacity(bar, aluminum, 20, 30); al >= cu {
+		t.Errorf("aluminum ampacity %.1f A not below copper %.1f A", al, cu)
+	}
+
+	// Wider bars of the same area cool better
+	if flat, square := busbarAmpacity(BusbarSize{40, 5}, copper, 20, 30), busbarAmpacity(BusbarSize{20, 10}, copper, 20, 30); flat <= square {
+		t.Errorf("40 × 5 mm (%.1f A) not above 20 × 10 mm (%.1f A)", flat, square)
+	}
+}
+
+func TestBusbarTempRise(t *testing.T) {
+	copper := materials["copper"]
+	bar := BusbarSize{Width: 20, Thickness: 5}
+
+	ampacity := busbarAmpacity(bar, copper, 25, 30)
+	if got := busbarTempRise(bar, ampacity, copper, 25); math.Abs(got-30) > 0.01 {
+		t.Errorf("rise at ampacity = %.3f K, want 30 K", got)
+	}
+	if got := busbarTempRise(bar, 0, copper, 25); got > 0.01 {
+		t.Errorf("rise without current = %v, want 0", got)
+	}
+	if got := busbarTempRise(bar, 1e6, copper, 25); !math.IsInf(got, 1) {
+		t.Errorf("rise at 1 MA = %v, want +Inf", got)
+	}
+}
+
+func TestBusbarResistance(t *testing.T) {
+	copper := materials["copper"]
+	// 0.0175 Ω·mm²/m × 0.5 m / 100 mm² = 87.5 μΩ
+	if got := busbarResistance(BusbarSize{20, 5}, 0.5, copper, 20); math.Abs(got-87.5e-6) > 1e-12 {
+		t.Errorf("got %v Ω, want 87.5 μΩ", got)
+	}
+}
+
+func TestBusbarDropPercent(t *testing.T) {
+	c := defaultCircuit()
+	c.Voltage = 12
+	c.Current = 100
+	c.Length = 2
+	copper := materials["copper"]
+	bar := BusbarSize{Width: 15, Thickness: 2}
+
+	// Resistance at the bar temperature, not at the ambient
+	rise := busbarTempRise(bar, c.Current, copper, c.AmbientTempCelsius)
+	want := c.Current * busbarResistance(bar, c.Length, copper, c.AmbientTempCelsius+rise) / c.Voltage * 100
+	if got := busbarDropPercent(c, bar, copper); math.Abs(got-want) > 1e-9 || got <= 1 {
+		t.Errorf("got %.4f%%, want %.4f%% (above 1%%)", got, want)
+	}
+
+	// Sunlight raises the bar temperature and the drop
+	sunny := c
+	sunny.SolarIrradiance = 1000
+	if got, shaded := busbarDropPercent(sunny, bar, copper), busbarDropPercent(c, bar, copper); got <= shaded {
+		t.Errorf("sunny %.4f%% not above shaded %.4f%%", got, shaded)
+	}
+}
+
+func TestRecommendBusbar(t *testing.T) {
+	c := defaultCircuit()
+	c.Voltage = 12
+	c.Current = 300
+	c.Length = 0.3
+
+	// Small drop area, ampacity decides: 30 × 3 and 20 × 5 carry less than 300 A
+	got, ok := recommendBusbar(standardBusbarSizes, c, 4.38, 30)
+	if !ok || got != (BusbarSize{40, 3}) {
+		t.Errorf("got %v, %v, want 40 × 3 mm", got, ok)
+	}
+
+	// Drop area decides: never below the required area
+	c.Current = 100
+	got, ok = recommendBusbar(standardBusbarSizes, c, 500, 30)
+	if !ok || got != (BusbarSize{50, 10}) {
+		t.Errorf("got %v, %v, want 50 × 10 mm", got, ok)
+	}
+
+	// 15 × 2 mm covers the 29.17 mm² required at the effective temperature
+	// but exceeds 1% at its own temperature
+	c.Length = 2
+	c.MaxVoltageDropPercent = 1
+	got, ok = recommendBusbar(standardBusbarSizes, c, 29.17, 30)
+	if !ok || got != (BusbarSize{20, 2}) {
+		t.Errorf("got %v, %v, want 20 × 2 mm", got, ok)
+	}
+
+	c.Current = 10000
+	if _, ok := recommendBusbar(standardBusbarSizes, c, 10, 30); ok {
+		t.Error("expected no busbar for 10 kA")
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Voltage drop charts
+//
+// Sweeps cable length or current and renders the voltage drop of every
+// standard size as an SVG line chart, with the maximum drop as horizontal
+// line and the operating point of the circuit highlighted.
+
+// Sweep variables of a drop chart
+const (
+	sweepLength  = "length"
+	sweepCurrent = "current"
+)
+
+// Chart dimensions (px)
+const (
+	chartWidth        = 800.0
+	chartHeight       = 500.0
+	chartMarginLeft   = 70.0
+	chartMarginRight  = 110.0
+	chartMarginTop    = 40.0
+	chartMarginBottom = 60.0
+)
+
+// Line colors, repeated when there are more curves than colors
+var chartColors = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b",
+	"#e377c2", "#7f7f7f", "#bcbd22", "#17becf",
+}
+
+// ChartPoint is a point of a chart series in data coordinates.
+type ChartPoint struct {
+	X, Y float64
+}
+
+// ChartSeries is one curve of a chart.
+type ChartSeries struct {
+	Label  string
+	Points []ChartPoint
+}
+
+// DropChart holds the data of a voltage drop chart.
+type DropChart struct {
+	Title          string
+	XLabel         string
+	XMax           float64
+	YMax           float64 // Voltage drop (%)
+	LimitPercent   float64
+	Series         []ChartSeries
+	OperatingPoint ChartPoint
+	OperatingLabel string
+}
+
+// Build a voltage drop chart for a circuit.
+//
+// The sweep variable runs from 0 to xMax in the given number of steps; all
+// other inputs are taken from the circuit. Drop in percent for size A:
+//
+//	drop% = I × ρ(T) × L × distanceFactor / A / V × 100
+func buildDropChart(c Circuit, sweep string, xMax float64, steps int) (DropChart, error) {
+	if err := c.validate(); err != nil {
+		return DropChart{}, err
+	}
+	maxDrop, err := c.maxDropPercent()
+	if err != nil {
+		return DropChart{}, err
+	}
+	conductorDrop, err := c.conductorDropPercent()
+	if err != nil {
+		return DropChart{}, err
+	}
+	if steps < 2 {
+		return DropChart{}, fmt.Errorf("chart needs at least 2 steps")
+	}
+	material, _, profile, _ := c.resolve()
+
+	operatingX := c.Length
+	xLabel := "Cable length (m)"
+	switch sweep {
+	case sweepLength:
+	case sweepCurrent:
+		operatingX = c.Current
+		xLabel = "Current (A)"
+	default:
+		return DropChart{}, fmt.Errorf("unknown sweep %q (length/current)", sweep)
+	}
+	if xMax <= 0 {
+		xMax = 2 * operatingX
+	}
+
+	dropPercent := func(x, area float64) float64 {
+		sc := c
+		if sweep == sweepLength {
+			sc.Length = x
+		} else {
+			sc.Current = x
+		}
+		return sc.voltageDrop(material, area) / c.Voltage * 100
+	}
+
+	requiredArea := calculateCableArea(c.Voltage, c.Current, c.Length, conductorDrop, material, c.RoundTrip, c.correctedAmbient(), c.Installation)
+	chosen := findSmallestSizeIn(profile.MetricSizes, requiredArea)
+
+	chart := DropChart{
+		Title:          fmt.Sprintf("Voltage drop at %.1f V, %s, %s", c.Voltage, material.Name, map[bool]string{true: "round trip", false: "one-way"}[c.RoundTrip]),
+		XLabel:         xLabel,
+		XMax:           xMax,
+		YMax:           3 * maxDrop,
+		LimitPercent:   maxDrop,
+		OperatingPoint: ChartPoint{X: operatingX, Y: dropPercent(operatingX, chosen)},
+		OperatingLabel: fmt.Sprintf("%.2f mm²", chosen),
+	}
+	chart.YMax = math.Max(chart.YMax, chart.OperatingPoint.Y*1.2)
+
+	for _, size := range profile.MetricSizes {
+		series := ChartSeries{Label: fmt.Sprintf("%g mm²", size)}
+		for i := 0; i < steps; i++ {
+			x := xMax * float64(i) / float64(steps-1)
+			series.Points = append(series.Points, ChartPoint{X: x, Y: dropPercent(x, size)})
+		}
+		chart.Series = append(chart.Series, series)
+	}
+
+	return chart, nil
+}
+
+// Clip a series to the chart's y range.
+//
+// Points are kept up to the first point above yMax; the crossing with yMax
+// is interpolated so the curve ends at the chart border.
+func clipSeries(points []ChartPoint, yMax float64) []ChartPoint {
+	var clipped []ChartPoint
+	for i, p := range points {
+		if p.Y <= yMax {
+			clipped = append(clipped, p)
+			continue
+		}
+		if i > 0 {
+			prev := points[i-1]
+			t := (yMax - prev.Y) / (p.Y - prev.Y)
+			clipped = append(clipped, ChartPoint{X: prev.X + t*(p.X-prev.X), Y: yMax})
+		}
+		break
+	}
+	return clipped
+}
+
+// Render a drop chart as SVG.
+func renderSVG(w io.Writer, chart DropChart) error {
+	plotWidth := chartWidth - chartMarginLeft - chartMarginRight
+	plotHeight := chartHeight - chartMarginTop - chartMarginBottom
+	px := func(x float64) float64 { return chartMarginLeft + x/chart.XMax*plotWidth }
+	py := func(y float64) float64 { return chartMarginTop + plotHeight - y/chart.YMax*plotHeight }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" font-family=\"sans-serif\" font-size=\"12\">\n", chartWidth, chartHeight)
+	fmt.Fprintf(&b, "<rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n")
+	fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"24\" text-anchor=\"middle\" font-size=\"15\">%s</text>\n", chartWidth/2, html.EscapeString(chart.Title))
+
+	// Grid and axis labels
+	const ticks = 5
+	for i := 0; i <= ticks; i++ {
+		x := chart.XMax * float64(i) / ticks
+		y := chart.YMax * float64(i) / ticks
+		fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#ddd\"/>\n", px(x), py(0), px(x), py(chart.YMax))
+		fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#ddd\"/>\n", px(0), py(y), px(chart.XMax), py(y))
+		fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\">%.3g</text>\n", px(x), py(0)+18, x)
+		fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"end\">%.3g</text>\n", px(0)-6, py(y)+4, y)
+	}
+	fmt.Fprintf(&b, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"none\" stroke=\"black\"/>\n", chartMarginLeft, chartMarginTop, plotWidth, plotHeight)
+	fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\">%s</text>\n", px(chart.XMax/2), chartHeight-15, html.EscapeString(chart.XLabel))
+	fmt.Fprintf(&b, "<text x=\"18\" y=\"%.1f\" text-anchor=\"middle\" transform=\"rotate(-90 18 %.1f)\">Voltage drop (%%)</text>\n", py(chart.YMax/2), py(chart.YMax/2))
+
+	// One curve per size, labelled at its end
+	for i, series := range chart.Series {
+		points := clipSeries(series.Points, chart.YMax)
+		if len(points) < 2 {
+			continue
+		}
+		color := chartColors[i%len(chartColors)]
+		coords := make([]string, len(points))
+		for j, p := range points {
+			coords[j] = fmt.Sprintf("%.1f,%.1f", px(p.X), py(p.Y))
+		}
+		fmt.Fprintf(&b, "<polyline fill=\"none\" stroke=\"%s\" stroke-width=\"1.5\" points=\"%s\"/>\n", color, strings.Join(coords, " "))
+		end := points[len(points)-1]
+		fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%.1f\" fill=\"%s\">%s</text>\n", px(end.X)+4, py(end.Y)+4, color, html.EscapeString(series.Label))
+	}
+
+	// Maximum drop
+	fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"red\" stroke-width=\"2\" stroke-dasharray=\"6 4\"/>\n", px(0), py(chart.LimitPercent), px(chart.XMax), py(chart.LimitPercent))
+	fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%.1f\" fill=\"red\">max %.2f%%</text>\n", px(0)+6, py(chart.LimitPercent)-6, chart.LimitPercent)
+
+	// Operating point
+	op := chart.OperatingPoint
+	fmt.Fprintf(&b, "<circle cx=\"%.1f\" cy=\"%.1f\" r=\"6\" fill=\"black\"/>\n", px(op.X), py(op.Y))
+	fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%.1f\" font-weight=\"bold\">%s: %.2f%%</text>\n", px(op.X)+10, py(op.Y)-10, html.EscapeString(chart.OperatingLabel), op.Y)
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Run the chart command.
+func runChartCommand(args []string) int {
+	fs := flag.NewFlagSet("chart", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "chart"
+	bindCircuitFlags(fs, &c)
+	sweep := fs.String("sweep", sweepLength, "variable to sweep (length/current)")
+	xMax := fs.Float64("max", 0, "end of the sweep range (default: twice the operating value)")
+	steps := fs.Int("steps", 50, "number of points per curve")
+	output := fs.String("o", "voltage-drop.svg", "output SVG file")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	chart, err := buildDropChart(c, *sweep, *xMax, *steps)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	defer f.Close()
+	if err := renderSVG(f, chart); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Printf("Chart written to %s (operating point: %s, %.2f%% drop)\n", *output, chart.OperatingLabel, chart.OperatingPoint.Y)
+	return exitOK
+}
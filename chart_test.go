@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBuildDropChart(t *testing.T) {
+	c := testCircuit("chart")
+	c.Current = 20
+
+	tests := []struct {
+		name       string
+		sweep      string
+		xMax       float64
+		wantXMax   float64
+		wantOpX    float64
+		wantErr    bool
+		wantLabel  string
+		wantXLabel string
+	}{
+		{
+			name:       "length sweep with default range",
+			sweep:      sweepLength,
+			wantXMax:   10.0,
+			wantOpX:    5.0,
+			wantLabel:  "10.00 mm²", // required 9.72 mm²
+			wantXLabel: "Cable length (m)",
+		},
+		{
+			name:       "current sweep with given range",
+			sweep:      sweepCurrent,
+			xMax:       50.0,
+			wantXMax:   50.0,
+			wantOpX:    20.0,
+			wantLabel:  "10.00 mm²",
+			wantXLabel: "Current (A)",
+		},
+		{
+			name:    "unknown sweep",
+			sweep:   "voltage",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart, err := buildDropChart(c, tt.sweep, tt.xMax, 20)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildDropChart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if chart.XMax != tt.wantXMax {
+				t.Errorf("XMax = %v, want %v", chart.XMax, tt.wantXMax)
+			}
+			if chart.XLabel != tt.wantXLabel {
+				t.Errorf("XLabel = %v, want %v", chart.XLabel, tt.wantXLabel)
+			}
+			if len(chart.Series) != len(standardMetricSizes) {
+				t.Errorf("got %d series, want %d", len(chart.Series), len(standardMetricSizes))
+			}
+			if chart.OperatingPoint.X != tt.wantOpX || chart.OperatingLabel != tt.wantLabel {
+				t.Errorf("operating point = %v %q, want x %v %q", chart.OperatingPoint, chart.OperatingLabel, tt.wantOpX, tt.wantLabel)
+			}
+			wantY := c.voltageDrop(materials["copper"], 10.0) / c.Voltage * 100
+			if math.Abs(chart.OperatingPoint.Y-wantY) > 0.0001 {
+				t.Errorf("operating drop = %v, want %v", chart.OperatingPoint.Y, wantY)
+			}
+			if chart.LimitPercent != c.MaxVoltageDropPercent {
+				t.Errorf("LimitPercent = %v, want %v", chart.LimitPercent, c.MaxVoltageDropPercent)
+			}
+		})
+	}
+}
+
+func TestClipSeries(t *testing.T) {
+	points := []ChartPoint{{0, 0}, {1, 2}, {2, 4}, {3, 6}}
+
+	got := clipSeries(points, 5)
+	want := []ChartPoint{{0, 0}, {1, 2}, {2, 4}, {2.5, 5}}
+	if len(got) != len(want) {
+		t.Fatalf("clipSeries() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i].X-want[i].X) > 1e-9 || math.Abs(got[i].Y-want[i].Y) > 1e-9 {
+			t.Errorf("clipSeries()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := clipSeries(points, 10); len(got) != len(points) {
+		t.Errorf("clipSeries() within range dropped points: %v", got)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	chart, err := buildDropChart(testCircuit("chart"), sweepLength, 0, 10)
+	if err != nil {
+		t.Fatalf("buildDropChart() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := renderSVG(&out, chart); err != nil {
+		t.Fatalf("renderSVG() error = %v", err)
+	}
+
+	// Output must be well-formed XML
+	decoder := xml.NewDecoder(strings.NewReader(out.String()))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+	}
+
+	for _, want := range []string{"<polyline", "max 3.00%", "<circle", chart.OperatingLabel} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+}
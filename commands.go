@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+
+	// Strict mode failure categories
+	exitTempExceeded        = 3 // A circuit exceeds its wire type's temperature rating
+	exitDropExceeded        = 4 // A chosen size exceeds the maximum voltage drop
+	exitBusbarOutOfRange    = 5 // No standard busbar carries the current
+	exitBusbarRiseExceeded  = 6 // A busbar's temperature rise exceeds the maximum
+	exitConduitFillExceeded = 7 // Cables do not fit a conduit within the fill ratio
+)
+
+// Run a subcommand given on the command line.
+//
+// Without a subcommand, main() runs the interactive single-cable calculator.
+// Returns the process exit code.
+func runCommand(name string, args []string, reader *bufio.Reader) int {
+	switch name {
+	case "pv":
+		return runPVWizard(reader, args)
+	case "project":
+		return runProjectCommand(args)
+	case "compare":
+		return runCompareCommand(args)
+	case "chart":
+		return runChartCommand(args)
+	case "sensitivity":
+		return runSensitivityCommand(args)
+	case "montecarlo":
+		return runMonteCarloCommand(args)
+	case "loadprofile":
+		return runLoadProfileCommand(args)
+	case "thermal":
+		return runThermalCommand(args)
+	case "pwm":
+		return runPWMCommand(args)
+	case "busbar":
+		return runBusbarCommand(args)
+	case "conduit":
+		return runConduitCommand(args)
+	case "help", "-h", "--help":
+		printUsage()
+		return exitOK
+	default:
+		fmt.Printf("Error: Unknown command %q.\n", name)
+		printUsage()
+		return exitUsage
+	}
+}
+
+func printUsage() {
+	fmt.Println("Usage: cablecalc [command]")
+	fmt.Println()
+	fmt.Println("Without a command, the interactive cable calculator is started.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  pv           Solar PV string and battery-to-inverter sizing wizard")
+	fmt.Println("  project      Create, edit, recalculate and report project files")
+	fmt.Println("  compare      Compare all materials side by side for one circuit")
+	fmt.Println("  chart        Plot voltage drop vs. length or current as SVG")
+	fmt.Println("  sensitivity  Show which input changes alter the recommended size")
+	fmt.Println("  montecarlo   Monte Carlo tolerance analysis of the voltage drop")
+	fmt.Println("  loadprofile  Size for peak voltage drop and RMS heating of a load profile")
+	fmt.Println("  thermal      Simulate the conductor temperature over a load profile")
+	fmt.Println("  pwm          Size for PWM/ripple currents (peak, RMS, skin effect)")
+	fmt.Println("  busbar       Size flat busbars (resistance, temperature rise, ampacity)")
+	fmt.Println("  conduit      Cable outer diameter and conduit fill check")
+	fmt.Println("  help         Show this help")
+}
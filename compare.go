@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Material comparison
+//
+// Runs calculateCableArea for every material with otherwise identical
+// inputs and compares required area, recommended size, outer diameter,
+// weight, cost and actual voltage drop side by side.
+
+// MaterialComparison is the result for one material.
+type MaterialComparison struct {
+	Material     CableMaterial
+	RequiredArea float64
+	Size         float64 // Recommended metric size (mm²)
+	Conductors   int     // Parallel conductors of Size (1 = single)
+	Diameter     float64 // Outer diameter of an insulated cable of Size (mm)
+	Estimate     CableEstimate
+	Drop         float64 // Actual voltage drop (V)
+	DropPercent  float64
+}
+
+// Load custom materials from a JSON file.
+//
+// The file holds a list of materials, e.g.
+//
+//	[{"name": "Silver", "resistivity_20c": 0.0159, "temp_coefficient": 0.0038,
+//	  "density": 10490, "price_per_kg": 900}]
+func loadCustomMaterials(path string) (map[string]CableMaterial, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var list []CableMaterial
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	custom := make(map[string]CableMaterial, len(list))
+	for _, m := range list {
+		if m.Name == "" || m.Resistivity20C <= 0 {
+			return nil, fmt.Errorf("%s: material needs a name and a positive resistivity", path)
+		}
+		custom[strings.ToLower(m.Name)] = m
+	}
+	return custom, nil
+}
+
+// Compare materials for a circuit.
+//
+// All inputs except the material are taken from the circuit. Results are
+// sorted by material name.
+func compareMaterials(c Circuit, candidates map[string]CableMaterial) ([]MaterialComparison, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	dropPercent, err := c.conductorDropPercent()
+	if err != nil {
+		return nil, err
+	}
+	_, _, profile, _ := c.resolve()
+
+	distanceFactor := 1.0
+	if c.RoundTrip {
+		distanceFactor = 2.0
+	}
+
+	results := make([]MaterialComparison, 0, len(candidates))
+	for _, material := range candidates {
+		r := MaterialComparison{Material: material, Conductors: 1}
+		r.RequiredArea = calculateCableArea(c.Voltage, c.Current, c.Length, dropPercent, material, c.RoundTrip, c.correctedAmbient(), c.Installation)
+		r.Size = findSmallestSizeIn(profile.MetricSizes, r.RequiredArea)
+		if outOfRange := checkOutOfRange(profile.MetricSizes, r.RequiredArea, c.Voltage); outOfRange != nil {
+			r.Size = outOfRange.ParallelSize
+			r.Conductors = outOfRange.ParallelCount
+		}
+
+		totalArea := r.Size * float64(r.Conductors)
+		r.Diameter = c.outerDiameter(r.Size)
+		r.Estimate = estimateCable(totalArea, c.Length*distanceFactor, material)
+		r.Drop = c.voltageDrop(material, totalArea)
+		r.DropPercent = r.Drop / c.Voltage * 100
+		results = append(results, r)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Material.Name < results[j].Material.Name
+	})
+	return results, nil
+}
+
+// Run the compare command.
+func runCompareCommand(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "compare"
+	bindCircuitFlags(fs, &c)
+	customPath := fs.String("materials", "", "JSON file with additional materials")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	candidates := make(map[string]CableMaterial, len(materials))
+	for key, material := range materials {
+		candidates[key] = material
+	}
+	if *customPath != "" {
+		custom, err := loadCustomMaterials(*customPath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		for key, material := range custom {
+			candidates[key] = material
+		}
+	}
+
+	results, err := compareMaterials(c, candidates)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	maxDrop, _ := c.maxDropPercent()
+
+	fmt.Println("=== Material Comparison ===")
+	fmt.Printf("%.1f V, %.2f A, %.2f m (%s), max drop %.2f%%, ambient %.1f°C, %s\n",
+		c.Voltage, c.Current, c.Length, map[bool]string{true: "round trip", false: "one-way"}[c.RoundTrip],
+		maxDrop, c.AmbientTempCelsius, c.Installation)
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Material\tRequired\tSize\tOuter Ø\tWeight\tCost\tDrop")
+	for _, r := range results {
+		size := fmt.Sprintf("%.2f mm²", r.Size)
+		if r.Conductors > 1 {
+			size = fmt.Sprintf("%d × %.2f mm²", r.Conductors, r.Size)
+		}
+		fmt.Fprintf(w, "%s\t%.2f mm²\t%s\t%.2f mm\t%.3f kg\t%.2f\t%.2f V (%.2f%%)\n",
+			r.Material.Name, r.RequiredArea, size, r.Diameter, r.Estimate.TotalMass, r.Estimate.Cost, r.Drop, r.DropPercent)
+	}
+	w.Flush()
+	return exitOK
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareMaterials(t *testing.T) {
+	c := testCircuit("compare")
+	c.Current = 30
+	c.Length = 4
+
+	results, err := compareMaterials(c, materials)
+	if err != nil {
+		t.Fatalf("compareMaterials() error = %v", err)
+	}
+	if len(results) != len(materials) {
+		t.Fatalf("compareMaterials() returned %d results, want %d", len(results), len(materials))
+	}
+
+	aluminum, copper := results[0], results[1]
+	if aluminum.Material.Name != "Aluminum" || copper.Material.Name != "Copper" {
+		t.Fatalf("unexpected order: %s, %s", aluminum.Material.Name, copper.Material.Name)
+	}
+
+	for _, r := range results {
+		want := calculateCableArea(c.Voltage, c.Current, c.Length, c.MaxVoltageDropPercent, r.Material, c.RoundTrip, c.AmbientTempCelsius, c.Installation)
+		if math.Abs(r.RequiredArea-want) > 0.0001 {
+			t.Errorf("%s required area = %v, want %v", r.Material.Name, r.RequiredArea, want)
+		}
+		if want := c.outerDiameter(r.Size); math.Abs(r.Diameter-want) > 0.0001 || r.Diameter <= areaToDiameter(r.Size) {
+			t.Errorf("%s outer diameter = %v, want %v", r.Material.Name, r.Diameter, want)
+		}
+	}
+
+	if aluminum.RequiredArea <= copper.RequiredArea {
+		t.Errorf("aluminum should need more area than copper: %v <= %v", aluminum.RequiredArea, copper.RequiredArea)
+	}
+	if aluminum.Estimate.TotalMass >= copper.Estimate.TotalMass {
+		t.Errorf("aluminum should be lighter than copper: %v >= %v", aluminum.Estimate.TotalMass, copper.Estimate.TotalMass)
+	}
+
+	// 4.86 mm² copper required: the closer 4 mm² would exceed the drop
+	c.Current = 10
+	c.Length = 5
+	results, err = compareMaterials(c, materials)
+	if err != nil {
+		t.Fatalf("compareMaterials() error = %v", err)
+	}
+	for _, r := range results {
+		if r.Size < r.RequiredArea || r.DropPercent > c.MaxVoltageDropPercent {
+			t.Errorf("%s: size %.2f mm² for %.2f mm² required, drop %.2f%%", r.Material.Name, r.Size, r.RequiredArea, r.DropPercent)
+		}
+	}
+}
+
+func TestLoadCustomMaterials(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("valid file", func(t *testing.T) {
+		path := filepath.Join(dir, "valid.json")
+		data := `[{"name": "Silver", "resistivity_20c": 0.0159, "temp_coefficient": 0.0038, "density": 10490, "price_per_kg": 900}]`
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		custom, err := loadCustomMaterials(path)
+		if err != nil {
+			t.Fatalf("loadCustomMaterials() error = %v", err)
+		}
+		silver, ok := custom["silver"]
+		if !ok {
+			t.Fatalf("silver not loaded: %+v", custom)
+		}
+		if silver.Resistivity20C != 0.0159 || silver.Density != 10490 || silver.PricePerKg != 900 {
+			t.Errorf("silver = %+v", silver)
+		}
+	})
+
+	t.Run("missing resistivity", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		if err := os.WriteFile(path, []byte(`[{"name": "Unobtainium"}]`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadCustomMaterials(path); err == nil {
+			t.Errorf("loadCustomMaterials() should fail without resistivity")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Series components
+//
+// Crimp terminals, connectors, switches and fuse holders take a share of
+// the voltage drop budget. A circuit lists its series components; their
+// drop at the circuit current is subtracted from the budget before the
+// conductor area is calculated.
+
+// Component is a series component with a typical contact resistance.
+type Component struct {
+	Name       string
+	Resistance float64 // Ω
+}
+
+// Typical contact resistances of series components
+var componentLibrary = map[string]Component{
+	"crimp":         {"Crimp terminal", 0.0001},
+	"bolted":        {"Bolted lug or busbar joint", 0.00005},
+	"blade-fuse":    {"Blade fuse in holder", 0.003},
+	"anl-fuse":      {"ANL/MEGA fuse in holder", 0.0003},
+	"breaker":       {"Thermal circuit breaker", 0.003},
+	"switch":        {"Battery isolator switch", 0.0003},
+	"rocker-switch": {"Panel rocker switch", 0.005},
+	"relay":         {"Automotive relay contact", 0.005},
+	"connector":     {"Multi-pin connector contact pair", 0.003},
+	"anderson":      {"Anderson-style power connector", 0.0003},
+}
+
+// Parse a comma-separated component list such as "crimp:4,blade-fuse".
+//
+// An optional ":n" gives the number of identical components. An empty
+// string returns no components.
+func parseComponents(s string) ([]string, error) {
+	var list []string
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if _, _, err := parseComponentEntry(entry); err != nil {
+			return nil, err
+		}
+		list = append(list, entry)
+	}
+	return list, nil
+}
+
+// Parse a single component list entry ("key" or "key:n").
+func parseComponentEntry(entry string) (Component, int, error) {
+	key, countStr, hasCount := strings.Cut(entry, ":")
+	component, ok := componentLibrary[key]
+	if !ok {
+		return Component{}, 0, fmt.Errorf("unknown component %q (%s)", key, strings.Join(componentKeys(), "/"))
+	}
+	count := 1
+	if hasCount {
+		var err error
+		count, err = strconv.Atoi(countStr)
+		if err != nil || count <= 0 {
+			return Component{}, 0, fmt.Errorf("invalid component count in %q", entry)
+		}
+	}
+	return component, count, nil
+}
+
+// Sorted keys of the component library.
+func componentKeys() []string {
+	keys := make([]string, 0, len(componentLibrary))
+	for key := range componentLibrary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Total resistance of a component list (Ω).
+func componentsResistance(list []string) (float64, error) {
+	var total float64
+	for _, entry := range list {
+		component, count, err := parseComponentEntry(entry)
+		if err != nil {
+			return 0, err
+		}
+		total += component.Resistance * float64(count)
+	}
+	return total, nil
+}
+
+// Calculate the voltage drop budget left for the conductor (%).
+//
+// Formula: drop%_conductor = drop% - I × R_components / V × 100
+//
+// Returns an error if the components alone use up the budget.
+func conductorDropBudget(voltage, current, maxVoltageDropPercent, componentResistance float64) (float64, error) {
+	componentPercent := current * componentResistance / voltage * 100
+	if componentPercent >= maxVoltageDropPercent {
+		return 0, fmt.Errorf("series components drop %.3f V (%.2f%%), leaving no budget for the conductor", current*componentResistance, componentPercent)
+	}
+	return maxVoltageDropPercent - componentPercent, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseComponents(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"crimp", 1, false},
+		{"Crimp:4, blade-fuse ,", 2, false},
+		{"crimp:0", 0, true},
+		{"crimp:x", 0, true},
+		{"solder", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseComponents(tt.input)
+		if (err != nil) != tt.wantErr || len(got) != tt.want {
+			t.Errorf("parseComponents(%q) = %v, %v, want %d entries, err %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestComponentsResistance(t *testing.T) {
+	got, err := componentsResistance([]string{"crimp:4", "blade-fuse", "relay"})
+	if err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	// 4 × 0.1 mΩ + 3 mΩ + 5 mΩ
+	if math.Abs(got-0.0084) > 1e-12 {
+		t.Errorf("got %v Ω, want 0.0084 Ω", got)
+	}
+	if got, _ := componentsResistance(nil); got != 0 {
+		t.Errorf("no components: got %v, want 0", got)
+	}
+}
+
+func TestConductorDropBudget(t *testing.T) {
+	// 20 A × 8.4 mΩ = 0.168 V = 1.4% of 12 V
+	got, err := conductorDropBudget(12, 20, 3, 0.0084)
+	if err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	if math.Abs(got-1.6) > 1e-9 {
+		t.Errorf("got %v%%, want 1.6%%", got)
+	}
+	if _, err := conductorDropBudget(12, 50, 3, 0.0084); err == nil {
+		t.Error("expected error when components use up the budget")
+	}
+}
+
+func TestCircuitComponents(t *testing.T) {
+	c := testCircuit("fridge")
+	c.Current = 20
+	if err := c.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	without := c.RequiredArea
+
+	c.Components = []string{"crimp:4", "blade-fuse", "relay"}
+	if err := c.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	// Budget 3% -> 1.6%: area scales with 3 / 1.6
+	if math.Abs(c.RequiredArea-without*3/1.6) > 1e-9 {
+		t.Errorf("RequiredArea = %v, want %v", c.RequiredArea, without*3/1.6)
+	}
+
+	// Total drop with the required area equals the full budget
+	material, _, _, _ := c.resolve()
+	if got := c.voltageDrop(material, c.RequiredArea) / c.Voltage * 100; math.Abs(got-3) > 1e-9 {
+		t.Errorf("total drop = %v%%, want 3%%", got)
+	}
+
+	c.Components = []string{"fuse"}
+	if err := c.recalculate(); err == nil {
+		t.Error("expected error for unknown component")
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Outer diameter and conduit fill
+//
+// The finished cable outer diameter is estimated from the conductor area,
+// the stranding factor and the insulation thickness of the wire type. The
+// conduit fill is the share of the conduit's inner cross-section taken by
+// the cables.
+
+// Default maximum conduit fill ratio
+const defaultConduitFill = 0.4
+
+// ConduitSize is a standard conduit with its inner diameter.
+type ConduitSize struct {
+	Name          string
+	InnerDiameter float64 // mm
+}
+
+// Typical inner diameters of rigid conduits (EN 61386, medium duty)
+var standardConduitSizes = []ConduitSize{
+	{"M16", 13.0},
+	{"M20", 16.9},
+	{"M25", 21.4},
+	{"M32", 27.8},
+	{"M40", 35.4},
+	{"M50", 44.3},
+	{"M63", 56.5},
+}
+
+// Estimate the outer diameter of a finished cable.
+//
+// Formula: OD = d_bundle + 2 × insulation thickness
+// Where d_bundle is the strand bundle diameter of the wire type's
+// stranding class.
+func cableOuterDiameter(area float64, wireType WireType) float64 {
+	return bundleDiameter(area, strandingClasses[wireType.Stranding]) + 2*wireType.InsulationThickness
+}
+
+// Outer diameter of a finished cable of a wire type, given by its key
+// (mm).
+//
+// ISO 6722 classes use the maximum outer diameter of the insulation wall
+// series from the size table where the size is defined, and return true.
+// SAE J1128 types are AWG cables with their own wall thickness and are
+// estimated like other wire types.
+func wireTypeOuterDiameter(key string, wireType WireType, area float64, wall InsulationWall) (float64, bool) {
+	if _, ok := iso6722TempClasses[key]; ok {
+		if diameter, ok := automotiveOuterDiameter(area, wall); ok {
+			return diameter, true
+		}
+	}
+	return cableOuterDiameter(area, wireType), false
+}
+
+// Outer diameter of a finished cable of the circuit's wire type (mm).
+//
+// ISO 6722 classes use the thin-wall series.
+func (c Circuit) outerDiameter(area float64) float64 {
+	diameter, _ := wireTypeOuterDiameter(c.WireType, c.wireTypeWithStranding(), area, WallThin)
+	return diameter
+}
+
+// Calculate the fill ratio of n equal cables in a conduit.
+//
+// Formula: fill = n × OD² / D²
+func conduitFill(outerDiameter float64, count int, innerDiameter float64) float64 {
+	return float64(count) * outerDiameter * outerDiameter / (innerDiameter * innerDiameter)
+}
+
+// Find the smallest standard conduit for n cables within a fill ratio.
+//
+// Returns false if no standard conduit is large enough.
+func findConduit(sizes []ConduitSize, outerDiameter float64, count int, maxFill float64) (ConduitSize, bool) {
+	sorted := append([]ConduitSize(nil), sizes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].InnerDiameter < sorted[j].InnerDiameter })
+	for _, size := range sorted {
+		if conduitFill(outerDiameter, count, size.InnerDiameter) <= maxFill {
+			return size, true
+		}
+	}
+	return ConduitSize{}, false
+}
+
+// Parse a conduit as standard size name (e.g. "M25") or inner diameter in mm.
+func parseConduit(s string) (ConduitSize, error) {
+	s = strings.TrimSpace(s)
+	for _, size := range standardConduitSizes {
+		if strings.EqualFold(size.Name, s) {
+			return size, nil
+		}
+	}
+	diameter, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(s), "mm"), 64)
+	if err != nil || diameter <= 0 {
+		return ConduitSize{}, fmt.Errorf("unknown conduit %q (M16-M63 or inner diameter in mm)", s)
+	}
+	return ConduitSize{Name: fmt.Sprintf("%g mm", diameter), InnerDiameter: diameter}, nil
+}
+
+// Run the conduit command.
+func runConduitCommand(args []string) int {
+	fs := flag.NewFlagSet("conduit", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "conduit"
+	bindCircuitFlags(fs, &c)
+	sizeStr := fs.String("size", "", "conductor size (mm² or AWG, default: recommended size)")
+	count := fs.Int("count", 2, "number of cables in the conduit")
+	conduitStr := fs.String("conduit", "", "conduit to check (M16-M63 or inner diameter in mm)")
+	maxFill := fs.Float64("fill", defaultConduitFill, "maximum fill ratio")
+	diagOpts := bindDiagnosticFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *count <= 0 || *maxFill <= 0 || *maxFill > 1 {
+		fmt.Println("Error: Cable count must be positive and the fill ratio between 0 and 1.")
+		return exitUsage
+	}
+
+	_, wireType, _, err := c.resolve()
+	if err == nil && c.Stranding != 0 {
+		_, err = lookupStrandingClass(c.Stranding)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	var area float64
+	var label string
+	if *sizeStr != "" {
+		if area, label, err = parseConductorSize(*sizeStr); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitUsage
+		}
+	} else {
+		if err := c.recalculate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		area = c.MetricSize
+		label = chosenSizeLabel(c)
+		if c.Conductors > 1 {
+			// Every parallel conductor is a separate cable
+			*count *= c.Conductors
+		}
+	}
+
+	outerDiameter := c.outerDiameter(area)
+	fmt.Println("=== Outer Diameter and Conduit Fill ===")
+	fmt.Printf("Cable: %s %s\n", label, wireType.Name)
+	fmt.Printf("Conductor: %s\n", describeStranding(area, c.strandingClass(), DisplayMetric))
+	fmt.Printf("Outer Diameter: %.2f mm\n", outerDiameter)
+	fmt.Printf("Cables: %d, maximum fill %.0f%%\n", *count, *maxFill*100)
+	fmt.Println()
+
+	var list []Diagnostic
+	if *conduitStr != "" {
+		conduit, err := parseConduit(*conduitStr)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitUsage
+		}
+		fill := conduitFill(outerDiameter, *count, conduit.InnerDiameter)
+		if list = collectDiagnostics(checkConduitFill(conduit, outerDiameter, fill, *maxFill)); list == nil {
+			fmt.Printf("%s (inner %.1f mm): fill %.0f%%, fits\n", conduit.Name, conduit.InnerDiameter, fill*100)
+		}
+	}
+
+	if conduit, ok := findConduit(standardConduitSizes, outerDiameter, *count, *maxFill); ok {
+		fmt.Printf("Smallest standard conduit: %s (inner %.1f mm, fill %.0f%%)\n", conduit.Name, conduit.InnerDiameter, conduitFill(outerDiameter, *count, conduit.InnerDiameter)*100)
+	} else {
+		fmt.Println("No standard conduit up to M63 is large enough.")
+	}
+	return diagOpts.report(list)
+}
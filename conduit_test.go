@@ -0,0 +1,117 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCableOuterDiameter(t *testing.T) {
+	// 10 mm² class 5: d = 3.568 mm / √0.72 + 2 × 0.8 mm
+	if got := cableOuterDiameter(10, wireTypes["pvc"]); math.Abs(got-5.805) > 0.001 {
+		t.Errorf("10 mm² PVC: got %.3f mm, want 5.805 mm", got)
+	}
+	// No stranding class: solid conductor
+	bare := WireType{Name: "Bare"}
+	if got := cableOuterDiameter(10, bare); math.Abs(got-areaToDiameter(10)) > 1e-9 {
+		t.Errorf("bare: got %v, want %v", got, areaToDiameter(10))
+	}
+}
+
+func TestCircuitOuterDiameter(t *testing.T) {
+	c := testCircuit("od")
+
+	c.WireType = "pvc"
+	if got, want := c.outerDiameter(10), cableOuterDiameter(10, wireTypes["pvc"]); got != want {
+		t.Errorf("pvc: got %v, want %v", got, want)
+	}
+	c.Stranding = 1
+	if got, want := c.outerDiameter(10), areaToDiameter(10)+1.6; math.Abs(got-want) > 1e-9 {
+		t.Errorf("pvc class 1: got %v, want %v", got, want)
+	}
+	c.Stranding = 0
+
+	// ISO 6722 classes use the size table
+	c.WireType = "c"
+	want, _ := automotiveOuterDiameter(2.5, WallThin)
+	if got := c.outerDiameter(2.5); got != want {
+		t.Errorf("class C 2.5 mm²: got %v, want table value %v", got, want)
+	}
+
+	// SAE J1128 types use their own wall thickness
+	for key, wall := range map[string]float64{"gxl": 0.6, "txl": 0.4, "sxl": 0.9} {
+		c.WireType = key
+		want := bundleDiameter(2.5, strandingClasses[5]) + 2*wall
+		if got := c.outerDiameter(2.5); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s 2.5 mm²: got %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestWireTypeOuterDiameter(t *testing.T) {
+	// ISO 6722 classes use the table value of the chosen wall series
+	want, _ := automotiveOuterDiameter(2.5, WallThick)
+	if got, ok := wireTypeOuterDiameter("b", iso6722TempClasses["b"], 2.5, WallThick); !ok || got != want {
+		t.Errorf("class B thick 2.5 mm²: got %v (%v), want table value %v", got, ok, want)
+	}
+	// Sizes outside the table are estimated
+	if _, ok := wireTypeOuterDiameter("b", iso6722TempClasses["b"], 0.2, WallThin); ok {
+		t.Error("class B 0.2 mm²: got a table value, want an estimate")
+	}
+	// SAE J1128 types never use the ISO 6722 table, whatever the wall
+	for _, key := range []string{"gxl", "txl", "sxl"} {
+		_, wall, _ := lookupAutomotiveWireType(key, WallThin)
+		got, ok := wireTypeOuterDiameter(key, wireTypes[key], 2.5, wall)
+		if want := cableOuterDiameter(2.5, wireTypes[key]); ok || got != want {
+			t.Errorf("%s 2.5 mm²: got %v (table %v), want estimate %v", key, got, ok, want)
+		}
+	}
+}
+
+func TestConduitFill(t *testing.T) {
+	// 3 × 5² / 20² = 0.1875
+	if got := conduitFill(5, 3, 20); math.Abs(got-0.1875) > 1e-12 {
+		t.Errorf("got %v, want 0.1875", got)
+	}
+}
+
+func TestFindConduit(t *testing.T) {
+	tests := []struct {
+		outerDiameter float64
+		count         int
+		maxFill       float64
+		want          string
+		wantOK        bool
+	}{
+		{5.7, 2, 0.4, "M16", true},  // 2 × 5.7² / 13² = 38%
+		{5.7, 3, 0.4, "M20", true},  // 57% in M16
+		{5.7, 3, 0.25, "M25", true}, // 21% in M25
+		{20, 10, 0.4, "", false},    // 4000 mm² of cable
+	}
+	for _, tt := range tests {
+		got, ok := findConduit(standardConduitSizes, tt.outerDiameter, tt.count, tt.maxFill)
+		if ok != tt.wantOK || got.Name != tt.want {
+			t.Errorf("findConduit(%v, %d, %v) = %v, %v, want %s, %v", tt.outerDiameter, tt.count, tt.maxFill, got.Name, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseConduit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"M25", 21.4, false},
+		{"m16", 13.0, false},
+		{"22", 22, false},
+		{"22mm", 22, false},
+		{"M99", 0, true},
+		{"-5", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseConduit(tt.input)
+		if (err != nil) != tt.wantErr || got.InnerDiameter != tt.want {
+			t.Errorf("parseConduit(%q) = %v, %v, want %v, err %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Diagnostics
+//
+// Checks report their findings as diagnostics with a stable code, a
+// severity, the affected input field and the parameters of the finding,
+// so callers can print them, write them as JSON or suppress them by code.
+
+// Severity is the level of a diagnostic.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityCaution Severity = "caution"
+	SeverityError   Severity = "error"
+)
+
+// Diagnostic codes
+const (
+	CodeTempExceeded   = "temp-exceeded"     // Effective temperature above the wire type rating
+	CodeTempNearLimit  = "temp-near-limit"   // Effective temperature above 90% of the rating
+	CodeDropExceeded   = "drop-exceeded"     // Chosen size exceeds the maximum voltage drop
+	CodeSizeOutOfRange = "size-out-of-range" // No single standard conductor is large enough
+	CodeAWGOutOfRange  = "awg-out-of-range"  // Required area above the AWG series
+
+	CodeSkinEffect          = "skin-effect"           // Skin effect raises the AC resistance by more than 10%
+	CodeBusbarOutOfRange    = "busbar-out-of-range"   // No standard busbar carries the current
+	CodeBusbarRiseExceeded  = "busbar-rise-exceeded"  // Busbar temperature rise above the maximum
+	CodeConduitFillExceeded = "conduit-fill-exceeded" // Cables do not fit the conduit within the fill ratio
+)
+
+// Severity of every diagnostic code
+var diagnosticCodes = map[string]Severity{
+	CodeTempExceeded:   SeverityError,
+	CodeTempNearLimit:  SeverityCaution,
+	CodeDropExceeded:   SeverityError,
+	CodeSizeOutOfRange: SeverityCaution,
+	CodeAWGOutOfRange:  SeverityInfo,
+
+	CodeSkinEffect:          SeverityCaution,
+	CodeBusbarOutOfRange:    SeverityError,
+	CodeBusbarRiseExceeded:  SeverityError,
+	CodeConduitFillExceeded: SeverityError,
+}
+
+// Label printed before the message of each severity
+var severityLabels = map[Severity]string{
+	SeverityInfo:    "NOTE",
+	SeverityCaution: "CAUTION",
+	SeverityError:   "WARNING",
+}
+
+// Diagnostic is a finding of a check.
+type Diagnostic struct {
+	Code     string         `json:"code"`
+	Severity Severity       `json:"severity"`
+	Circuit  string         `json:"circuit,omitempty"`
+	Field    string         `json:"field"` // Affected input (JSON name of the circuit field, or command flag)
+	Message  string         `json:"message"`
+	Params   map[string]any `json:"params,omitempty"`
+}
+
+// Create a diagnostic with the severity of its code.
+func newDiagnostic(code, field, message string, params map[string]any) *Diagnostic {
+	return &Diagnostic{Code: code, Severity: diagnosticCodes[code], Field: field, Message: message, Params: params}
+}
+
+// Describe a diagnostic on one line.
+func (d Diagnostic) String() string {
+	if d.Circuit != "" {
+		return fmt.Sprintf("%s: %s [%s]", d.Circuit, d.Message, d.Code)
+	}
+	return fmt.Sprintf("%s [%s]", d.Message, d.Code)
+}
+
+// Check the effective operating temperature against the wire type's rating.
+//
+// Returns an error above the rating, a caution above 90% of it and nil
+// otherwise.
+func checkWireTemperature(effectiveTempCelsius float64, wireType WireType) *Diagnostic {
+	params := map[string]any{
+		"effective_temp_c": roundSignificant(effectiveTempCelsius, 4),
+		"max_temp_c":       wireType.MaxTempCelsius,
+		"wire_type":        wireType.Name,
+	}
+	if effectiveTempCelsius > wireType.MaxTempCelsius {
+		return newDiagnostic(CodeTempExceeded, "wire_type",
+			fmt.Sprintf("Effective operating temperature (%.1f°C) exceeds %s maximum rating (%.0f°C)! Wire insulation may fail.", effectiveTempCelsius, wireType.Name, wireType.MaxTempCelsius),
+			params)
+	}
+
+	// Warn if within 10% of maximum
+	if effectiveTempCelsius > wireType.MaxTempCelsius*0.9 {
+		return newDiagnostic(CodeTempNearLimit, "wire_type",
+			fmt.Sprintf("Effective operating temperature (%.1f°C) is close to %s maximum rating (%.0f°C). Consider using a higher temperature rated wire.", effectiveTempCelsius, wireType.Name, wireType.MaxTempCelsius),
+			params)
+	}
+	return nil
+}
+
+// Check the voltage drop of a chosen size against the maximum voltage drop.
+func checkVoltageDrop(sizeLabel string, dropPercent, maxDropPercent float64) *Diagnostic {
+	if dropPercent <= maxDropPercent+1e-9 {
+		return nil
+	}
+	return newDiagnostic(CodeDropExceeded, "max_voltage_drop_percent",
+		fmt.Sprintf("Voltage drop with %s (%.2f%%) exceeds the maximum of %.2f%%. Use the next larger size.", sizeLabel, dropPercent, maxDropPercent),
+		map[string]any{
+			"size":             sizeLabel,
+			"drop_percent":     roundSignificant(dropPercent, 4),
+			"max_drop_percent": maxDropPercent,
+		})
+}
+
+// Describe an out-of-range result as a diagnostic.
+func outOfRangeDiagnostic(r *OutOfRangeResult) *Diagnostic {
+	params := map[string]any{
+		"required_area":  roundSignificant(r.RequiredArea, 4),
+		"largest_size":   r.LargestSize,
+		"parallel_count": r.ParallelCount,
+		"parallel_size":  r.ParallelSize,
+	}
+	if r.SuggestedVoltage > 0 {
+		params["suggested_voltage"] = r.SuggestedVoltage
+	}
+	msg := fmt.Sprintf("No single standard conductor satisfies the requirement: required %.2f mm² exceeds the largest size %.2f mm²; using %d × %.2f mm² in parallel.", r.RequiredArea, r.LargestSize, r.ParallelCount, r.ParallelSize)
+	if r.SuggestedVoltage > 0 {
+		msg += fmt.Sprintf(" A %.0f V system at the same load power requires %.2f mm².", r.SuggestedVoltage, r.SuggestedArea)
+	}
+	return newDiagnostic(CodeSizeOutOfRange, "current", msg, params)
+}
+
+// Check whether the required area exceeds a series of AWG sizes.
+//
+// Areas below the smallest size are not reported: the smallest size still
+// satisfies them.
+func awgRangeDiagnostic(sizes []AWGSize, requiredArea float64) *Diagnostic {
+	largest := sizes[len(sizes)-1]
+	if requiredArea > largest.Area {
+		return newDiagnostic(CodeAWGOutOfRange, "awg_size",
+			fmt.Sprintf("Required area (%.2f mm²) exceeds the largest supported AWG size %s (%.2f mm²).", requiredArea, largest.Label, largest.Area),
+			map[string]any{"required_area": roundSignificant(requiredArea, 4), "limit": largest.Label})
+	}
+	return nil
+}
+
+// Check the temperature of a transient simulation against the wire type's
+// rating.
+func checkTransientTemp(sim ThermalSimulation, wireType WireType) *Diagnostic {
+	if !sim.ReachedMax {
+		return nil
+	}
+	return newDiagnostic(CodeTempExceeded, "wire_type",
+		fmt.Sprintf("%s maximum rating (%.0f°C) reached after %.1f s.", wireType.Name, wireType.MaxTempCelsius, sim.TimeToMax),
+		map[string]any{
+			"peak_temp_c":   roundSignificant(sim.PeakTemp, 4),
+			"max_temp_c":    wireType.MaxTempCelsius,
+			"wire_type":     wireType.Name,
+			"time_to_max_s": roundSignificant(sim.TimeToMax, 4),
+		})
+}
+
+// Check the AC resistance increase of a conductor from skin effect.
+//
+// Reports ratios Rac/Rdc above 1.1.
+func checkSkinEffect(acRatio float64) *Diagnostic {
+	if acRatio <= 1.1 {
+		return nil
+	}
+	return newDiagnostic(CodeSkinEffect, "frequency",
+		fmt.Sprintf("Skin effect raises the AC resistance by %.0f%%. Consider parallel smaller conductors or litz wire.", (acRatio-1)*100),
+		map[string]any{"ac_ratio": roundSignificant(acRatio, 4)})
+}
+
+// Describe a current no standard busbar carries within the maximum
+// temperature rise.
+func busbarOutOfRangeDiagnostic(current, maxRise float64) *Diagnostic {
+	return newDiagnostic(CodeBusbarOutOfRange, "current",
+		fmt.Sprintf("No standard busbar carries %.2f A within a %.0f K temperature rise. Consider parallel busbars.", current, maxRise),
+		map[string]any{"current": current, "max_rise_k": maxRise})
+}
+
+// Check the temperature rise of a busbar against the maximum.
+func checkBusbarRise(rise, maxRise float64) *Diagnostic {
+	if rise <= maxRise {
+		return nil
+	}
+	return newDiagnostic(CodeBusbarRiseExceeded, "max-rise",
+		fmt.Sprintf("Temperature rise (%.1f K) exceeds %.0f K.", rise, maxRise),
+		map[string]any{"rise_k": roundSignificant(rise, 4), "max_rise_k": maxRise})
+}
+
+// Check whether cables of an outer diameter fit a conduit within the
+// maximum fill ratio.
+func checkConduitFill(conduit ConduitSize, outerDiameter, fill, maxFill float64) *Diagnostic {
+	params := map[string]any{
+		"conduit":        conduit.Name,
+		"inner_diameter": conduit.InnerDiameter,
+		"fill":           roundSignificant(fill, 4),
+		"max_fill":       maxFill,
+	}
+	switch {
+	case outerDiameter > conduit.InnerDiameter:
+		return newDiagnostic(CodeConduitFillExceeded, "conduit",
+			fmt.Sprintf("%s (inner %.1f mm): cable does not fit.", conduit.Name, conduit.InnerDiameter), params)
+	case fill > maxFill:
+		return newDiagnostic(CodeConduitFillExceeded, "conduit",
+			fmt.Sprintf("%s (inner %.1f mm): fill %.0f%% exceeds %.0f%%.", conduit.Name, conduit.InnerDiameter, fill*100, maxFill*100), params)
+	}
+	return nil
+}
+
+// Parse a comma-separated list of diagnostic codes to suppress.
+func parseSuppressedCodes(s string) (map[string]bool, error) {
+	suppressed := make(map[string]bool)
+	for _, code := range strings.Split(s, ",") {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if _, ok := diagnosticCodes[code]; !ok {
+			codes := make([]string, 0, len(diagnosticCodes))
+			for c := range diagnosticCodes {
+				codes = append(codes, c)
+			}
+			slices.Sort(codes)
+			return nil, fmt.Errorf("unknown diagnostic code %q (known: %s)", code, strings.Join(codes, ", "))
+		}
+		suppressed[code] = true
+	}
+	return suppressed, nil
+}
+
+// Diagnostic options of a command.
+type diagnosticOptions struct {
+	Suppressed map[string]bool
+	Strict     bool // Fail with a non-zero exit code on error-level diagnostics
+}
+
+// Strict mode exit codes of the error-level codes, by priority: if several
+// categories fail, only the first one in this list determines the exit
+// code, the others are not reported in it.
+var strictExitCodes = []struct {
+	Code string
+	Exit int
+}{
+	{CodeTempExceeded, exitTempExceeded},
+	{CodeDropExceeded, exitDropExceeded},
+	{CodeBusbarOutOfRange, exitBusbarOutOfRange},
+	{CodeBusbarRiseExceeded, exitBusbarRiseExceeded},
+	{CodeConduitFillExceeded, exitConduitFillExceeded},
+}
+
+// Register the -suppress and -strict flags.
+func bindDiagnosticFlags(fs *flag.FlagSet) *diagnosticOptions {
+	opts := &diagnosticOptions{Suppressed: make(map[string]bool)}
+	fs.Func("suppress", "comma-separated diagnostic codes to hide, e.g. temp-near-limit", func(s string) error {
+		codes, err := parseSuppressedCodes(s)
+		for code := range codes {
+			opts.Suppressed[code] = true
+		}
+		return err
+	})
+	fs.BoolVar(&opts.Strict, "strict", false, "exit with a non-zero code on error-level diagnostics, including suppressed ones")
+	return opts
+}
+
+// Exit code for a list of diagnostics.
+//
+// Pass the list before filterDiagnostics: suppressing a code hides it but
+// does not pass strict mode. Without strict mode this is always exitOK. In
+// strict mode every error-level diagnostic fails with the exit code of its
+// category in strictExitCodes, or exitError for a code without one. Only
+// the code of the highest-priority failure is returned.
+func (o *diagnosticOptions) exitCode(list []Diagnostic) int {
+	if !o.Strict {
+		return exitOK
+	}
+	failed := make(map[string]bool)
+	for _, d := range list {
+		if d.Severity == SeverityError {
+			failed[d.Code] = true
+		}
+	}
+	if len(failed) == 0 {
+		return exitOK
+	}
+	for _, s := range strictExitCodes {
+		if failed[s.Code] {
+			return s.Exit
+		}
+	}
+	return exitError
+}
+
+// Exit code for a list of diagnostics printed as text, with a note on a
+// strict mode failure.
+func (o *diagnosticOptions) reportExitCode(list []Diagnostic) int {
+	code := o.exitCode(list)
+	if code != exitOK {
+		fmt.Printf("\nStrict mode: error-level diagnostics, exit code %d.\n", code)
+	}
+	return code
+}
+
+// Print the unsuppressed diagnostics of a command after a blank line and
+// return its exit code.
+func (o *diagnosticOptions) report(list []Diagnostic) int {
+	if shown := filterDiagnostics(list, o.Suppressed); len(shown) > 0 {
+		fmt.Println()
+		printDiagnostics(os.Stdout, shown)
+	}
+	return o.reportExitCode(list)
+}
+
+// Collect the findings of checks into a list, skipping nil results.
+func collectDiagnostics(findings ...*Diagnostic) []Diagnostic {
+	var list []Diagnostic
+	for _, d := range findings {
+		if d != nil {
+			list = append(list, *d)
+		}
+	}
+	return list
+}
+
+// Remove suppressed diagnostics from a list.
+func filterDiagnostics(list []Diagnostic, suppressed map[string]bool) []Diagnostic {
+	var kept []Diagnostic
+	for _, d := range list {
+		if !suppressed[d.Code] {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// Diagnostics of a calculated circuit.
+//
+// Circuits that were not calculated yet have none.
+func (c Circuit) diagnostics() []Diagnostic {
+	material, wireType, profile, err := c.resolve()
+	if err != nil || c.MetricSize == 0 {
+		return nil
+	}
+
+	var list []Diagnostic
+	add := func(d *Diagnostic) {
+		if d != nil {
+			d.Circuit = c.Name
+			list = append(list, *d)
+		}
+	}
+	add(checkWireTemperature(c.effectiveTemp(), wireType))
+	if r := checkOutOfRange(profile.MetricSizes, c.RequiredArea, c.Voltage); r != nil {
+		add(outOfRangeDiagnostic(r))
+	}
+	maxDrop, _ := c.maxDropPercent()
+	add(checkVoltageDrop(chosenSizeLabel(c), c.voltageDrop(material, c.chosenArea())/c.Voltage*100, maxDrop))
+	add(awgRangeDiagnostic(profile.AWGSizes, c.RequiredArea))
+	return list
+}
+
+// Diagnostics of all circuits of a project, including the bundled
+// temperatures of its harness sections.
+func (p *Project) diagnostics() ([]Diagnostic, error) {
+	var list []Diagnostic
+	for _, c := range p.Circuits {
+		list = append(list, c.diagnostics()...)
+	}
+	if len(p.Sections) == 0 {
+		return list, nil
+	}
+
+	_, circuits, err := analyzeHarness(p)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range circuits {
+		if r.Diagnostic == nil {
+			continue
+		}
+		// The circuit's own check already covers an unbundled effective temperature
+		if c := p.findCircuit(r.Circuit); c != nil && r.BundledTemp <= c.effectiveTemp() {
+			continue
+		}
+		// Copy the parameters, the harness result shares them
+		d := *r.Diagnostic
+		d.Circuit = r.Circuit
+		d.Field = "sections"
+		d.Params = maps.Clone(d.Params)
+		d.Params["section"] = r.Section
+		d.Params["derating"] = r.Factor
+		list = append(list, d)
+	}
+	return list, nil
+}
+
+// Print diagnostics, one per line, with the label of their severity.
+func printDiagnostics(w io.Writer, list []Diagnostic) {
+	for _, d := range list {
+		fmt.Fprintf(w, "⚠️  %s: %s\n", severityLabels[d.Severity], d)
+	}
+}
+
+// Print a single diagnostic, if any.
+func printDiagnostic(w io.Writer, d *Diagnostic) {
+	if d != nil {
+		printDiagnostics(w, []Diagnostic{*d})
+	}
+}
+
+// Write diagnostics as a JSON array.
+func writeDiagnosticsJSON(w io.Writer, list []Diagnostic) error {
+	if list == nil {
+		list = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(list)
+}
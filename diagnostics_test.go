@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCheckWireTemperature(t *testing.T) {
+	pvc := wireTypes["pvc"]
+
+	tests := []struct {
+		temp     float64
+		wantCode string
+	}{
+		{50, ""},
+		{63, ""},
+		{65, CodeTempNearLimit},
+		{75, CodeTempExceeded},
+	}
+	for _, tt := range tests {
+		d := checkWireTemperature(tt.temp, pvc)
+		switch {
+		case tt.wantCode == "" && d != nil:
+			t.Errorf("%.0f°C: got %s, want none", tt.temp, d.Code)
+		case tt.wantCode != "" && (d == nil || d.Code != tt.wantCode):
+			t.Errorf("%.0f°C: got %v, want %s", tt.temp, d, tt.wantCode)
+		case d != nil && (d.Field != "wire_type" || d.Params["max_temp_c"] != 70.0):
+			t.Errorf("%.0f°C: got field %q, params %v", tt.temp, d.Field, d.Params)
+		}
+
+		// ValidateWireTemperature wraps the same check
+		valid, msg := ValidateWireTemperature(tt.temp, pvc)
+		if valid != (tt.wantCode != CodeTempExceeded) || (msg != "") != (d != nil) {
+			t.Errorf("%.0f°C: ValidateWireTemperature() = %v, %q, inconsistent with %v", tt.temp, valid, msg, d)
+		}
+	}
+
+	if got := diagnosticCodes[CodeTempExceeded]; got != SeverityError {
+		t.Errorf("temp-exceeded severity = %s, want error", got)
+	}
+}
+
+func TestCheckVoltageDrop(t *testing.T) {
+	if d := checkVoltageDrop("4.00 mm²", 3.0, 3.0); d != nil {
+		t.Errorf("drop at the limit: got %v, want none", d)
+	}
+	d := checkVoltageDrop("4.00 mm²", 3.65, 3.0)
+	if d == nil || d.Code != CodeDropExceeded || d.Severity != SeverityError {
+		t.Fatalf("got %v, want an error-level drop-exceeded", d)
+	}
+	if d.Params["size"] != "4.00 mm²" || d.Params["drop_percent"] != 3.65 {
+		t.Errorf("params = %v", d.Params)
+	}
+}
+
+func TestCommandDiagnostics(t *testing.T) {
+	conduit := ConduitSize{Name: "M20", InnerDiameter: 16.9}
+	generic := wireTypes["generic"]
+	tests := []struct {
+		name     string
+		d        *Diagnostic
+		wantCode string
+	}{
+		{"skin effect below 10%", checkSkinEffect(1.05), ""},
+		{"skin effect", checkSkinEffect(1.4), CodeSkinEffect},
+		{"busbar rise within", checkBusbarRise(25, 30), ""},
+		{"busbar rise exceeded", checkBusbarRise(42, 30), CodeBusbarRiseExceeded},
+		{"no busbar", busbarOutOfRangeDiagnostic(5000, 30), CodeBusbarOutOfRange},
+		{"conduit fits", checkConduitFill(conduit, 5, 0.2, 0.4), ""},
+		{"conduit fill exceeded", checkConduitFill(conduit, 8, 0.45, 0.4), CodeConduitFillExceeded},
+		{"cable does not fit", checkConduitFill(conduit, 18, 1.1, 0.4), CodeConduitFillExceeded},
+		{"transient within rating", checkTransientTemp(ThermalSimulation{PeakTemp: 60}, generic), ""},
+		{"transient rating reached", checkTransientTemp(ThermalSimulation{PeakTemp: 95, ReachedMax: true, TimeToMax: 42}, generic), CodeTempExceeded},
+	}
+	for _, tt := range tests {
+		switch {
+		case tt.wantCode == "" && tt.d != nil:
+			t.Errorf("%s: got %v, want none", tt.name, tt.d)
+		case tt.wantCode != "" && (tt.d == nil || tt.d.Code != tt.wantCode):
+			t.Errorf("%s: got %v, want %s", tt.name, tt.d, tt.wantCode)
+		case tt.d != nil && tt.d.Severity != diagnosticCodes[tt.wantCode]:
+			t.Errorf("%s: severity %s, want %s", tt.name, tt.d.Severity, diagnosticCodes[tt.wantCode])
+		}
+	}
+}
+
+func TestPrintDiagnostics(t *testing.T) {
+	var buf bytes.Buffer
+	d := checkWireTemperature(75, wireTypes["pvc"])
+	d.Circuit = "lights"
+	printDiagnostics(&buf, []Diagnostic{*d, *checkWireTemperature(65, wireTypes["pvc"])})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "⚠️  WARNING: lights: Effective") || !strings.HasSuffix(lines[0], "[temp-exceeded]") {
+		t.Errorf("error line = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "⚠️  CAUTION: Effective") {
+		t.Errorf("caution line = %q", lines[1])
+	}
+
+	// The printer adds the label, the message has none
+	if strings.Contains(d.Message, "WARNING") {
+		t.Errorf("message = %q, want no severity label", d.Message)
+	}
+
+	buf.Reset()
+	printDiagnostic(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("nil diagnostic printed %q", buf.String())
+	}
+}
+
+func TestParseSuppressedCodes(t *testing.T) {
+	got, err := parseSuppressedCodes("temp-near-limit, AWG-out-of-range,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !got[CodeTempNearLimit] || !got[CodeAWGOutOfRange] {
+		t.Errorf("got %v", got)
+	}
+	if _, err := parseSuppressedCodes("too-hot"); err == nil || !strings.Contains(err.Error(), "temp-exceeded") {
+		t.Errorf("expected an error listing the known codes, got %v", err)
+	}
+
+	list := []Diagnostic{{Code: CodeTempNearLimit}, {Code: CodeDropExceeded}}
+	if kept := filterDiagnostics(list, got); len(kept) != 1 || kept[0].Code != CodeDropExceeded {
+		t.Errorf("filterDiagnostics() = %v", kept)
+	}
+}
+
+func TestCircuitDiagnostics(t *testing.T) {
+	c := testCircuit("lights")
+	if got := c.diagnostics(); got != nil {
+		t.Errorf("not calculated: got %v, want none", got)
+	}
+
+	// 4.86 mm² required, 6 mm² chosen
+	if err := c.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.diagnostics(); len(got) != 0 {
+		t.Errorf("6 mm²: got %v, want none", got)
+	}
+
+	// An undersized 4 mm², e.g. from an older project file, drops 3.65%
+	c.MetricSize = 4
+	list := c.diagnostics()
+	if len(list) != 1 || list[0].Code != CodeDropExceeded || list[0].Circuit != "lights" {
+		t.Fatalf("got %v, want one drop-exceeded for lights", list)
+	}
+
+	c.WireType = "pvc"
+	c.AmbientTempCelsius = 75
+	c.Current = 600
+	if err := c.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	codes := map[string]bool{}
+	for _, d := range c.diagnostics() {
+		codes[d.Code] = true
+	}
+	if !codes[CodeTempExceeded] || !codes[CodeSizeOutOfRange] {
+		t.Errorf("got codes %v, want temp-exceeded and size-out-of-range", codes)
+	}
+}
+
+func TestProjectDiagnosticsHarness(t *testing.T) {
+	p := &Project{Name: "Van"}
+	var names []string
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		c := testCircuit(name)
+		c.Current = 25
+		c.WireType = "pvc"
+		c.AmbientTempCelsius = 50
+		p.Circuits = append(p.Circuits, c)
+		names = append(names, name)
+	}
+	if err := p.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	before, err := p.diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Sections = []HarnessSection{{Name: "main", Circuits: names}}
+	after, err := p.diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundled []Diagnostic
+	for _, d := range after {
+		if d.Field == "sections" {
+			bundled = append(bundled, d)
+		}
+	}
+	if len(after) != len(before)+len(bundled) || len(bundled) != len(names) {
+		t.Fatalf("got %d diagnostics (%d bundled), want %d plus one per circuit", len(after), len(bundled), len(before))
+	}
+	if bundled[0].Params["section"] != "main" {
+		t.Errorf("params = %v", bundled[0].Params)
+	}
+}
+
+func TestWriteDiagnosticsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDiagnosticsJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty list: got %q, want []", buf.String())
+	}
+
+	buf.Reset()
+	d := checkVoltageDrop("4.00 mm²", 3.65, 3.0)
+	d.Circuit = "lights"
+	if err := writeDiagnosticsJSON(&buf, []Diagnostic{*d}); err != nil {
+		t.Fatal(err)
+	}
+	var got []Diagnostic
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Code != CodeDropExceeded || got[0].Severity != SeverityError || got[0].Circuit != "lights" {
+		t.Errorf("round trip: got %+v", got)
+	}
+}
+
+func TestDiagnosticOptionsExitCode(t *testing.T) {
+	temp := Diagnostic{Code: CodeTempExceeded, Severity: SeverityError}
+	drop := Diagnostic{Code: CodeDropExceeded, Severity: SeverityError}
+	near := Diagnostic{Code: CodeTempNearLimit, Severity: SeverityCaution}
+
+	lenient := &diagnosticOptions{}
+	if got := lenient.exitCode([]Diagnostic{temp, drop}); got != exitOK {
+		t.Errorf("without strict mode: got %d, want %d", got, exitOK)
+	}
+
+	strict := &diagnosticOptions{Strict: true}
+	tests := []struct {
+		name string
+		list []Diagnostic
+		want int
+	}{
+		{"none", nil, exitOK},
+		{"caution only", []Diagnostic{near}, exitOK},
+		{"temperature", []Diagnostic{near, temp}, exitTempExceeded},
+		{"drop", []Diagnostic{drop}, exitDropExceeded},
+		{"both", []Diagnostic{drop, temp}, exitTempExceeded},
+		{"busbar", []Diagnostic{{Code: CodeBusbarRiseExceeded, Severity: SeverityError}}, exitBusbarRiseExceeded},
+		{"conduit", []Diagnostic{{Code: CodeConduitFillExceeded, Severity: SeverityError}}, exitConduitFillExceeded},
+		{"other error", []Diagnostic{{Code: "future-check", Severity: SeverityError}}, exitError},
+	}
+	for _, tt := range tests {
+		if got := strict.exitCode(tt.list); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// Every error-level code has an exit code of its own
+	exits := map[int]string{exitOK: "ok", exitError: "error", exitUsage: "usage"}
+	for code, severity := range diagnosticCodes {
+		if severity != SeverityError {
+			continue
+		}
+		got := strict.exitCode([]Diagnostic{{Code: code, Severity: severity}})
+		if other, ok := exits[got]; ok {
+			t.Errorf("%s: exit code %d already used by %s", code, got, other)
+		}
+		exits[got] = code
+	}
+}
+
+func TestBindDiagnosticFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts := bindDiagnosticFlags(fs)
+	if err := fs.Parse([]string{"-strict", "-suppress", "drop-exceeded", "-suppress", "temp-near-limit"}); err != nil {
+		t.Fatal(err)
+	}
+	if !opts.Strict || !opts.Suppressed[CodeDropExceeded] || !opts.Suppressed[CodeTempNearLimit] {
+		t.Errorf("got %+v", opts)
+	}
+
+	// Suppressed errors are hidden but still fail strict mode
+	list := []Diagnostic{{Code: CodeDropExceeded, Severity: SeverityError}}
+	if shown := filterDiagnostics(list, opts.Suppressed); len(shown) != 0 {
+		t.Errorf("suppressed drop-exceeded shown: %v", shown)
+	}
+	if got := opts.exitCode(list); got != exitDropExceeded {
+		t.Errorf("suppressed drop-exceeded: got %d, want %d", got, exitDropExceeded)
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	bindDiagnosticFlags(fs)
+	if err := fs.Parse([]string{"-suppress", "too-hot"}); err == nil {
+		t.Error("expected an error for an unknown code")
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import "math"
+
+// Altitude and solar radiation corrections
+//
+// calculateEffectiveTemp adds a fixed installation offset to the ambient
+// temperature. Two environmental effects raise the operating temperature
+// further:
+//
+//   - Reduced air density at altitude weakens convective cooling, so every
+//     temperature rise caused by poor cooling grows.
+//   - Direct sunlight heats the cable surface on top of the ambient.
+//
+// Buried installations are cooled by the soil and see no sunlight, so
+// neither correction applies to them. Solar gain applies to cables in free
+// air only: conduit and thermal insulation shade the cable, and their low
+// heat transfer coefficient would turn the surface heat balance into an
+// unbounded rise.
+
+const (
+	airDensityScaleHeight = 8400.0 // Scale height of the atmosphere (m)
+	solarAbsorptivity     = 0.9    // Absorptivity of dark cable insulation
+	maxSolarIrradiance    = 1400.0 // Upper bound of direct solar irradiance (W/m²)
+)
+
+// Calculate the air density at an altitude relative to sea level.
+//
+// Formula: ρ/ρ0 = e^(-h / H)
+// Where:
+//   - h = altitude above sea level (m)
+//   - H = scale height of the atmosphere (8400 m)
+func airDensityRatio(altitude float64) float64 {
+	return math.Exp(-altitude / airDensityScaleHeight)
+}
+
+// Calculate the factor by which temperature rises grow at an altitude.
+//
+// Natural convection scales with the square root of the air density, so
+// the temperature rise for the same heat scales with its inverse.
+//
+// Formula: k_alt = 1 / √(ρ/ρ0)
+//
+// Altitudes at or below sea level return 1.
+func altitudeCorrectionFactor(altitude float64) float64 {
+	if altitude <= 0 {
+		return 1
+	}
+	return 1 / math.Sqrt(airDensityRatio(altitude))
+}
+
+// Calculate the temperature rise of a cable in direct sunlight.
+//
+// Heat balance per metre of cable: the projected width d absorbs the
+// radiation and the circumference π × d dissipates it:
+//
+//	α × G × d = h × π × d × ΔT
+//
+// Formula: ΔT = α × G / (π × h)
+// Where:
+//   - α = absorptivity of the insulation (0.9)
+//   - G = direct solar irradiance (W/m²), about 1000 W/m² in full sun
+//   - h = heat transfer coefficient of the installation (W/m²K)
+//
+// Returns 0 for installations other than free air, which are not exposed.
+func solarTempRise(irradiance float64, installation InstallationMethod) float64 {
+	h := installationHeatTransfer[installation]
+	if irradiance <= 0 || h <= 0 || installation != InstallationInAir {
+		return 0
+	}
+	return solarAbsorptivity * irradiance / (math.Pi * h)
+}
+
+// Calculate the temperature rise added by altitude and solar radiation on
+// top of calculateEffectiveTemp.
+//
+// Formula: ΔT_env = (ΔT_installation + ΔT_solar) × k_alt - ΔT_installation
+func environmentTempRise(installation InstallationMethod, altitude, irradiance float64) float64 {
+	if isBuried(installation) {
+		return 0
+	}
+	offset := installationTempAdjustments[installation]
+	return (offset+solarTempRise(irradiance, installation))*altitudeCorrectionFactor(altitude) - offset
+}
+
+// Calculate the effective operating temperature including altitude and
+// solar radiation corrections.
+//
+// Formula: T = T_ambient + (ΔT_installation + ΔT_solar) × k_alt
+func calculateCorrectedEffectiveTemp(ambientTempCelsius float64, installation InstallationMethod, altitude, irradiance float64) float64 {
+	return calculateEffectiveTemp(ambientTempCelsius, installation) + environmentTempRise(installation, altitude, irradiance)
+}
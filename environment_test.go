@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAltitudeCorrectionFactor(t *testing.T) {
+	if got := altitudeCorrectionFactor(0); got != 1 {
+		t.Errorf("sea level: got %v, want 1", got)
+	}
+	if got := airDensityRatio(3000); math.Abs(got-0.70) > 0.01 {
+		t.Errorf("air density at 3000 m: got %.3f, want about 0.70", got)
+	}
+	// 1 / √0.70 ≈ 1.195
+	if got := altitudeCorrectionFactor(3000); math.Abs(got-1.195) > 0.001 {
+		t.Errorf("3000 m: got %.4f, want about 1.195", got)
+	}
+}
+
+func TestSolarTempRise(t *testing.T) {
+	if got := solarTempRise(0, InstallationInAir); got != 0 {
+		t.Errorf("shaded: got %v, want 0", got)
+	}
+	// 0.9 × 1000 / (π × 10) ≈ 28.6 K
+	if got := solarTempRise(1000, InstallationInAir); math.Abs(got-28.65) > 0.01 {
+		t.Errorf("full sun in air: got %.2f K, want about 28.65 K", got)
+	}
+
+	// Only cables in free air are exposed to the sun
+	for installation := range installationTempAdjustments {
+		got := solarTempRise(1000, installation)
+		if exposed := installation == InstallationInAir; exposed != (got > 0) {
+			t.Errorf("%s: got %.2f K, want a rise only in free air", installation, got)
+		}
+	}
+}
+
+func TestCalculateCorrectedEffectiveTemp(t *testing.T) {
+	if got, want := calculateCorrectedEffectiveTemp(30, InstallationConduit, 0, 0), calculateEffectiveTemp(30, InstallationConduit); got != want {
+		t.Errorf("no corrections: got %v, want %v", got, want)
+	}
+
+	// 30 + 10 × e^(2000/16800) ≈ 41.26°C
+	if got := calculateCorrectedEffectiveTemp(30, InstallationConduit, 2000, 0); math.Abs(got-41.26) > 0.01 {
+		t.Errorf("conduit at 2000 m: got %.2f°C, want about 41.26°C", got)
+	}
+
+	// In free air there is no offset to scale, only the solar rise
+	got := calculateCorrectedEffectiveTemp(30, InstallationInAir, 3000, 1000)
+	want := 30 + solarTempRise(1000, InstallationInAir)*altitudeCorrectionFactor(3000)
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("air at 3000 m in full sun: got %.2f°C, want %.2f°C", got, want)
+	}
+}
+
+func TestCircuitEnvironmentCorrections(t *testing.T) {
+	c := defaultCircuit()
+	c.Name = "roof"
+	c.Voltage = 12
+	c.Current = 20
+	c.Length = 5
+	c.AmbientTempCelsius = 40
+	c.WireType = "pvc"
+
+	shaded := c
+	if err := shaded.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.AltitudeMeters = 3000
+	c.SolarIrradiance = 1000
+	if err := c.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	if c.RequiredArea <= shaded.RequiredArea {
+		t.Errorf("expected sun to raise the required area: %.3f <= %.3f mm²", c.RequiredArea, shaded.RequiredArea)
+	}
+
+	// 40 + 34.2 K exceeds the 70°C PVC rating
+	_, wireType, _, _ := c.resolve()
+	valid, warning := ValidateWireTemperature(c.effectiveTemp(), wireType)
+	if valid || !strings.Contains(warning, "exceeds") {
+		t.Errorf("expected the corrected temperature to exceed the rating, got %v, %q", valid, warning)
+	}
+
+	c.SolarIrradiance = -1
+	if err := c.validate(); err == nil {
+		t.Error("expected an error for negative irradiance")
+	}
+	c.SolarIrradiance = 0
+	c.AltitudeMeters = -10
+	if err := c.validate(); err == nil {
+		t.Error("expected an error for negative altitude")
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Harness bundles
+//
+// A harness section is a stretch of the route map where several circuits
+// run in one bundle. The bundle outer diameter is estimated from the
+// insulated cable diameters, and the grouping derating of the bundle is
+// applied to the sizing and temperature check of every circuit in it.
+
+// HarnessSection is a section of a harness with the circuits routed through it.
+type HarnessSection struct {
+	Name     string   `json:"name"`
+	Circuits []string `json:"circuits"`
+}
+
+// Empirical factor between √Σd² and the outer diameter of a cable bundle
+const harnessBundleFactor = 1.2
+
+// Grouping derating factors for bunched circuits in air or on a surface
+// (IEC 60364-5-52, table B.52.17), by number of loaded circuits
+var groupingFactors = []struct {
+	Circuits int
+	Factor   float64
+}{
+	{1, 1.00}, {2, 0.80}, {3, 0.70}, {4, 0.65}, {5, 0.60}, {6, 0.57},
+	{7, 0.54}, {8, 0.52}, {9, 0.50}, {12, 0.45}, {16, 0.41}, {20, 0.38},
+}
+
+// Look up the grouping derating factor for a number of circuits.
+//
+// Counts between table entries use the next larger entry; more than 20
+// circuits use the factor for 20.
+func groupingFactor(circuits int) float64 {
+	for _, g := range groupingFactors {
+		if circuits <= g.Circuits {
+			return g.Factor
+		}
+	}
+	return groupingFactors[len(groupingFactors)-1].Factor
+}
+
+// Estimate the outer diameter of a bundle of cables.
+//
+// Formula: D = 1.2 × √Σd², but at least the largest cable diameter
+func harnessBundleDiameter(diameters []float64) float64 {
+	var sum, largest float64
+	for _, d := range diameters {
+		sum += d * d
+		largest = math.Max(largest, d)
+	}
+	if len(diameters) == 1 {
+		return largest
+	}
+	return math.Max(largest, harnessBundleFactor*math.Sqrt(sum))
+}
+
+// Number of cables a circuit contributes to a bundle: the parallel
+// conductors, doubled for round trip (supply and return).
+func (c Circuit) cableCount() int {
+	n := 1
+	if c.Conductors > 1 {
+		n = c.Conductors
+	}
+	if c.RoundTrip {
+		n *= 2
+	}
+	return n
+}
+
+// Find a harness section by name.
+func (p *Project) findSection(name string) *HarnessSection {
+	for i := range p.Sections {
+		if p.Sections[i].Name == name {
+			return &p.Sections[i]
+		}
+	}
+	return nil
+}
+
+// SectionResult is the bundle of one harness section.
+type SectionResult struct {
+	Section  HarnessSection
+	Cables   int
+	Diameter float64 // Bundle outer diameter (mm)
+	Factor   float64 // Grouping derating factor
+}
+
+// HarnessCircuitResult is the temperature check of a circuit in its most
+// derated harness section.
+type HarnessCircuitResult struct {
+	Circuit     string
+	Section     string
+	Factor      float64
+	SingleTemp  float64     // Estimated conductor temperature as a single cable (°C)
+	BundledTemp float64     // Estimated conductor temperature in the bundle (°C)
+	Diagnostic  *Diagnostic // Temperature check of the bundled conductor (nil if OK)
+}
+
+// Find the most derated section of every circuit routed through a harness
+// section, keyed by circuit name.
+func (p *Project) worstSections() (map[string]SectionResult, error) {
+	worst := map[string]SectionResult{}
+	for _, s := range p.Sections {
+		r := SectionResult{Section: s, Factor: groupingFactor(len(s.Circuits))}
+		for _, name := range s.Circuits {
+			if p.findCircuit(name) == nil {
+				return nil, fmt.Errorf("section %q: circuit %q not found", s.Name, name)
+			}
+			if w, ok := worst[name]; !ok || r.Factor < w.Factor {
+				worst[name] = r
+			}
+		}
+	}
+	return worst, nil
+}
+
+// Step up the chosen size of every bundled circuit until its conductor
+// stays within the wire type's rating in its most derated section.
+//
+// Circuits whose largest size still runs too hot keep the largest size;
+// their temperature check reports it.
+func (p *Project) applyHarnessDerating() error {
+	worst, err := p.worstSections()
+	if err != nil {
+		return err
+	}
+	for i := range p.Circuits {
+		c := &p.Circuits[i]
+		section, ok := worst[c.Name]
+		if !ok || c.MetricSize == 0 {
+			continue
+		}
+		material, wireType, profile, err := c.resolve()
+		if err != nil {
+			return err
+		}
+		current := c.Current / float64(max(c.Conductors, 1)) / section.Factor
+		chosen := c.MetricSize
+		for _, size := range profile.MetricSizes {
+			if size < chosen {
+				continue
+			}
+			c.MetricSize = size
+			if estimateConductorTemp(current, size, material, c.correctedAmbient(), c.Installation) <= wireType.MaxTempCelsius {
+				break
+			}
+		}
+		if c.MetricSize != chosen && c.Conductors <= 1 {
+			c.AWGSize, _ = findSmallestAWGIn(profile.AWGSizes, c.MetricSize)
+		}
+	}
+	return nil
+}
+
+// Analyze the harness sections of a calculated project.
+//
+// In a bundle derated by factor f, a conductor heats up like a single
+// conductor carrying I / f, so the bundled temperature is
+// estimateConductorTemp at I / f. The temperature check uses the higher
+// of the bundled temperature and the circuit's effective temperature.
+func analyzeHarness(p *Project) ([]SectionResult, []HarnessCircuitResult, error) {
+	worst, err := p.worstSections()
+	if err != nil {
+		return nil, nil, err
+	}
+	sections := make([]SectionResult, 0, len(p.Sections))
+	for _, s := range p.Sections {
+		r := SectionResult{Section: s, Factor: groupingFactor(len(s.Circuits))}
+		var diameters []float64
+		for _, name := range s.Circuits {
+			c := p.findCircuit(name)
+			if c.MetricSize == 0 {
+				return nil, nil, fmt.Errorf("section %q: circuit %q not calculated", s.Name, name)
+			}
+			d := c.outerDiameter(c.MetricSize)
+			for i := 0; i < c.cableCount(); i++ {
+				diameters = append(diameters, d)
+			}
+		}
+		r.Cables = len(diameters)
+		r.Diameter = harnessBundleDiameter(diameters)
+		sections = append(sections, r)
+	}
+
+	var circuits []HarnessCircuitResult
+	for _, c := range p.Circuits {
+		section, ok := worst[c.Name]
+		if !ok {
+			continue
+		}
+		material, wireType, _, err := c.resolve()
+		if err != nil {
+			return nil, nil, err
+		}
+		current := c.Current / float64(max(c.Conductors, 1))
+		r := HarnessCircuitResult{
+			Circuit:     c.Name,
+			Section:     section.Section.Name,
+			Factor:      section.Factor,
+			SingleTemp:  estimateConductorTemp(current, c.MetricSize, material, c.correctedAmbient(), c.Installation),
+			BundledTemp: estimateConductorTemp(current/section.Factor, c.MetricSize, material, c.correctedAmbient(), c.Installation),
+		}
+		r.Diagnostic = checkWireTemperature(math.Max(r.BundledTemp, c.effectiveTemp()), wireType)
+		circuits = append(circuits, r)
+	}
+	return sections, circuits, nil
+}
+
+// Print the harness report of a project.
+func printHarnessReport(p *Project) error {
+	sections, circuits, err := analyzeHarness(p)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("=== Harness: %s ===\n", p.Name)
+	if len(sections) == 0 {
+		fmt.Println("No sections.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Section\tCircuits\tCables\tBundle OD\tDerating")
+	for _, s := range sections {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.1f mm\t%.2f\n", s.Section.Name, strings.Join(s.Section.Circuits, ", "), s.Cables, s.Diameter, s.Factor)
+	}
+	w.Flush()
+	fmt.Println()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Circuit\tSection\tDerating\tSingle\tBundled\tTemperature")
+	for _, c := range circuits {
+		temperature := "OK"
+		if c.Diagnostic != nil {
+			temperature = "CAUTION"
+			if c.Diagnostic.Severity == SeverityError {
+				temperature = "EXCEEDED"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.1f°C\t%.1f°C\t%s\n", c.Circuit, c.Section, c.Factor, c.SingleTemp, c.BundledTemp, temperature)
+	}
+	w.Flush()
+	return nil
+}
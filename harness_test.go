@@ -0,0 +1,142 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGroupingFactor(t *testing.T) {
+	tests := []struct {
+		circuits int
+		want     float64
+	}{
+		{1, 1.00},
+		{3, 0.70},
+		{9, 0.50},
+		{10, 0.45}, // Next larger entry (12)
+		{20, 0.38},
+		{40, 0.38},
+	}
+	for _, tt := range tests {
+		if got := groupingFactor(tt.circuits); got != tt.want {
+			t.Errorf("groupingFactor(%d) = %v, want %v", tt.circuits, got, tt.want)
+		}
+	}
+}
+
+func TestHarnessBundleDiameter(t *testing.T) {
+	if got := harnessBundleDiameter([]float64{5}); got != 5 {
+		t.Errorf("single cable: got %v, want 5", got)
+	}
+	// 1.2 × √(4 × 3²) = 7.2
+	if got := harnessBundleDiameter([]float64{3, 3, 3, 3}); math.Abs(got-7.2) > 1e-9 {
+		t.Errorf("4 × 3 mm: got %v, want 7.2", got)
+	}
+	// One large cable dominates: at least its own diameter
+	if got := harnessBundleDiameter([]float64{20, 1}); got < 20 {
+		t.Errorf("20 + 1 mm: got %v, want at least 20", got)
+	}
+	if got := harnessBundleDiameter(nil); got != 0 {
+		t.Errorf("empty: got %v, want 0", got)
+	}
+}
+
+func TestAnalyzeHarness(t *testing.T) {
+	lights := testCircuit("lights")
+	pump := testCircuit("pump")
+	pump.Current = 15
+	single := testCircuit("single")
+	single.RoundTrip = false
+	p := &Project{
+		Name:     "Van",
+		Circuits: []Circuit{lights, pump, single},
+		Sections: []HarnessSection{
+			{Name: "main", Circuits: []string{"lights", "pump", "single"}},
+			{Name: "rear", Circuits: []string{"lights"}},
+		},
+	}
+	if err := p.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+
+	sections, circuits, err := analyzeHarness(p)
+	if err != nil {
+		t.Fatalf("analyzeHarness() error = %v", err)
+	}
+	if len(sections) != 2 || len(circuits) != 3 {
+		t.Fatalf("got %d sections and %d circuits, want 2 and 3", len(sections), len(circuits))
+	}
+
+	mainSection := sections[0]
+	if mainSection.Cables != 5 || mainSection.Factor != 0.70 {
+		t.Errorf("main: %d cables, factor %v, want 5 and 0.70", mainSection.Cables, mainSection.Factor)
+	}
+	if mainSection.Diameter <= sections[1].Diameter {
+		t.Errorf("main bundle %.1f mm not larger than rear %.1f mm", mainSection.Diameter, sections[1].Diameter)
+	}
+
+	for _, c := range circuits {
+		if c.Section != "main" {
+			t.Errorf("%s: section %q, want most derated section main", c.Circuit, c.Section)
+		}
+		if c.BundledTemp <= c.SingleTemp {
+			t.Errorf("%s: bundled %.1f°C not above single %.1f°C", c.Circuit, c.BundledTemp, c.SingleTemp)
+		}
+	}
+
+	// Heating scales with (I / f)²
+	material := materials["copper"]
+	pc := p.findCircuit("pump")
+	want := estimateConductorTemp(15/0.70, pc.MetricSize, material, 20, InstallationInAir)
+	if got := circuits[1].BundledTemp; math.Abs(got-want) > 1e-9 {
+		t.Errorf("pump bundled temperature = %v, want %v", got, want)
+	}
+
+	t.Run("unknown circuit", func(t *testing.T) {
+		bad := *p
+		bad.Sections = []HarnessSection{{Name: "x", Circuits: []string{"winch"}}}
+		if _, _, err := analyzeHarness(&bad); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestApplyHarnessDerating(t *testing.T) {
+	p := &Project{Name: "Van"}
+	var names []string
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		c := testCircuit(name)
+		c.Current = 25
+		c.Length = 2
+		c.WireType = "pvc"
+		c.AmbientTempCelsius = 40
+		p.Circuits = append(p.Circuits, c)
+		names = append(names, name)
+	}
+	if err := p.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	single := p.Circuits[0].MetricSize // 6 mm² for the voltage drop
+
+	p.Sections = []HarnessSection{{Name: "main", Circuits: names}}
+	if err := p.recalculate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Circuits[0].MetricSize; got <= single {
+		t.Errorf("bundled size %v mm², want larger than single %v mm²", got, single)
+	}
+	list, err := p.diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range list {
+		if d.Field == "sections" && d.Severity == SeverityError {
+			t.Errorf("derated sizes still fail in the bundle: %v", d)
+		}
+	}
+
+	p.Sections = []HarnessSection{{Name: "main", Circuits: []string{"a", "winch"}}}
+	if err := p.recalculate(); err == nil {
+		t.Error("expected an error for an unknown circuit")
+	}
+}
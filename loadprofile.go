@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Load profiles
+//
+// Loads such as winches, starter motors and inverters draw large currents
+// only briefly. The voltage drop is sized for the peak current, the heating
+// for the RMS current over the profile.
+
+// LoadStep is a constant current for a duration.
+type LoadStep struct {
+	Duration float64 // s
+	Current  float64 // A
+}
+
+// LoadProfile is a sequence of load steps, repeated periodically.
+type LoadProfile struct {
+	Steps []LoadStep
+}
+
+// Total duration of a load profile (s).
+func (p LoadProfile) Duration() float64 {
+	var total float64
+	for _, s := range p.Steps {
+		total += s.Duration
+	}
+	return total
+}
+
+// Peak current of a load profile (A).
+func (p LoadProfile) Peak() float64 {
+	var peak float64
+	for _, s := range p.Steps {
+		peak = math.Max(peak, math.Abs(s.Current))
+	}
+	return peak
+}
+
+// RMS current of a load profile (A).
+//
+// Formula: I_rms = √(Σ(I² × t) / T)
+func (p LoadProfile) RMS() float64 {
+	total := p.Duration()
+	if total == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range p.Steps {
+		sum += s.Current * s.Current * s.Duration
+	}
+	return math.Sqrt(sum / total)
+}
+
+// Build a load profile from a duty cycle.
+//
+// The peak current flows for dutyPercent of the period, the continuous
+// current for the rest.
+func dutyCycleProfile(peak, continuous, dutyPercent, period float64) LoadProfile {
+	on := period * dutyPercent / 100
+	return LoadProfile{Steps: []LoadStep{
+		{Duration: on, Current: peak},
+		{Duration: period - on, Current: continuous},
+	}}
+}
+
+// Read a load profile from CSV.
+//
+// Each row holds the duration (s) and current (A) of one step:
+//
+//	seconds,amps
+//	5,300
+//	55,10
+//
+// A header row and lines starting with # are skipped.
+func readLoadProfile(r io.Reader) (LoadProfile, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+
+	var p LoadProfile
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return LoadProfile{}, err
+		}
+		duration, errD := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		current, errC := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if errD != nil || errC != nil {
+			if line == 1 {
+				continue // Header
+			}
+			return LoadProfile{}, fmt.Errorf("line %d: invalid step %q", line, strings.Join(record, ","))
+		}
+		if duration < 0 {
+			return LoadProfile{}, fmt.Errorf("line %d: negative duration", line)
+		}
+		p.Steps = append(p.Steps, LoadStep{Duration: duration, Current: current})
+	}
+	if p.Duration() <= 0 {
+		return LoadProfile{}, errors.New("load profile has no duration")
+	}
+	return p, nil
+}
+
+// Load a load profile from a CSV file.
+func loadLoadProfile(path string) (LoadProfile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return LoadProfile{}, err
+	}
+	defer f.Close()
+	return readLoadProfile(f)
+}
+
+// LoadProfileResult is the sizing of a circuit for a load profile.
+type LoadProfileResult struct {
+	Peak          float64 // A
+	RMS           float64 // A
+	Drop          Circuit // Circuit sized for the voltage drop at peak current
+	ThermalSize   float64 // Smallest size within the temperature rating at RMS current (0 if none)
+	Size          float64 // Recommended size per conductor (mm²)
+	Conductors    int     // Parallel conductors of Size (0 = single)
+	ConductorTemp float64 // Estimated conductor temperature with Size at RMS current (°C)
+}
+
+// Size a circuit for a load profile.
+//
+// The voltage drop is sized for the peak current, the heating for the RMS
+// current. The recommended size is the larger of both.
+func sizeForLoadProfile(c Circuit, p LoadProfile) (LoadProfileResult, error) {
+	result := LoadProfileResult{Peak: p.Peak(), RMS: p.RMS()}
+
+	result.Drop = c
+	result.Drop.Current = result.Peak
+	if err := result.Drop.recalculate(); err != nil {
+		return result, err
+	}
+	material, wireType, profile, _ := c.resolve()
+
+	conductors := 1
+	if result.Drop.Conductors > 1 {
+		conductors = result.Drop.Conductors
+	}
+	current := result.RMS / float64(conductors)
+
+	result.Size = result.Drop.MetricSize
+	result.Conductors = result.Drop.Conductors
+	if size, ok := findThermalSize(profile.MetricSizes, current, material, c.correctedAmbient(), c.Installation, wireType.MaxTempCelsius); ok {
+		result.ThermalSize = size
+		result.Size = math.Max(result.Size, size)
+	}
+	result.ConductorTemp = estimateConductorTemp(current, result.Size, material, c.correctedAmbient(), c.Installation)
+	return result, nil
+}
+
+// Run the loadprofile command.
+func runLoadProfileCommand(args []string) int {
+	fs := flag.NewFlagSet("loadprofile", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "loadprofile"
+	bindCircuitFlags(fs, &c)
+	peak := fs.Float64("peak", 0, "peak current (A); -current is the continuous current")
+	duty := fs.Float64("duty", 10, "share of the period at peak current (%)")
+	period := fs.Float64("period", 60, "duty cycle period (s)")
+	loadFile := fs.String("load", "", "load profile CSV file (seconds,amps)")
+	diagOpts := bindDiagnosticFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var p LoadProfile
+	switch {
+	case *loadFile != "":
+		var err error
+		if p, err = loadLoadProfile(*loadFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+	case *peak > 0:
+		if *duty < 0 || *duty > 100 || *period <= 0 {
+			fmt.Println("Error: Duty cycle must be between 0 and 100% and the period positive.")
+			return exitUsage
+		}
+		p = dutyCycleProfile(*peak, c.Current, *duty, *period)
+	default:
+		fmt.Println("Error: Give a load profile with -load or a duty cycle with -peak.")
+		return exitUsage
+	}
+
+	result, err := sizeForLoadProfile(c, p)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	material, wireType, _, _ := c.resolve()
+
+	fmt.Println("=== Load Profile Sizing ===")
+	fmt.Printf("Profile duration: %.1f s\n", p.Duration())
+	fmt.Printf("Peak current: %.2f A\n", result.Peak)
+	fmt.Printf("RMS current: %.2f A\n", result.RMS)
+	fmt.Println()
+	fmt.Printf("Voltage drop at peak current: %.2f mm² required, %s\n", result.Drop.RequiredArea, chosenSizeLabel(result.Drop))
+	if result.ThermalSize > 0 {
+		fmt.Printf("Heating at RMS current: %.2f mm² (%s ≤ %.0f°C)\n", result.ThermalSize, wireType.Name, wireType.MaxTempCelsius)
+	} else {
+		fmt.Printf("Heating at RMS current: no standard size stays within %.0f°C\n", wireType.MaxTempCelsius)
+	}
+
+	sized := result.Drop
+	sized.MetricSize = result.Size
+	fmt.Printf("Recommended size: %s\n", chosenSizeLabel(sized))
+	fmt.Printf("Voltage drop at peak current: %.2f%%\n", sized.voltageDrop(material, sized.chosenArea())/c.Voltage*100)
+	fmt.Printf("Estimated conductor temperature at RMS current: %.1f°C\n", result.ConductorTemp)
+
+	return diagOpts.report(collectDiagnostics(checkWireTemperature(result.ConductorTemp, wireType)))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLoadProfile(t *testing.T) {
+	p := dutyCycleProfile(300, 10, 5, 60)
+
+	if got := p.Duration(); got != 60 {
+		t.Errorf("Duration() = %v, want 60", got)
+	}
+	if got := p.Peak(); got != 300 {
+		t.Errorf("Peak() = %v, want 300", got)
+	}
+	// √((300² × 3 + 10² × 57) / 60) = 67.79 A
+	if got := p.RMS(); math.Abs(got-67.79) > 0.01 {
+		t.Errorf("RMS() = %.2f, want 67.79", got)
+	}
+
+	if got := (LoadProfile{}).RMS(); got != 0 {
+		t.Errorf("empty RMS() = %v, want 0", got)
+	}
+}
+
+func TestReadLoadProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		steps   int
+		wantErr bool
+	}{
+		{"with header", "seconds,amps\n5,300\n55,10\n", 2, false},
+		{"without header", "5,300\n55,10\n", 2, false},
+		{"comments", "# winch\n5, 300\n", 1, false},
+		{"invalid step", "5,300\nx,10\n", 0, true},
+		{"negative duration", "-5,300\n", 0, true},
+		{"empty", "seconds,amps\n", 0, true},
+		{"wrong field count", "5,300,1\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := readLoadProfile(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(p.Steps) != tt.steps {
+				t.Errorf("got %d steps, want %d", len(p.Steps), tt.steps)
+			}
+		})
+	}
+}
+
+func TestSizeForLoadProfile(t *testing.T) {
+	c := testCircuit("winch")
+	c.Length = 3
+
+	t.Run("drop dominates", func(t *testing.T) {
+		result, err := sizeForLoadProfile(c, dutyCycleProfile(300, 10, 5, 60))
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		if result.Size != 95 {
+			t.Errorf("Size = %v, want 95 (drop at 300 A)", result.Size)
+		}
+		if result.ThermalSize >= result.Size {
+			t.Errorf("ThermalSize = %v, want smaller than %v", result.ThermalSize, result.Size)
+		}
+	})
+
+	t.Run("heating dominates", func(t *testing.T) {
+		c := c
+		c.MaxVoltageDropPercent = 10
+		c.Installation = InstallationIsolated
+		result, err := sizeForLoadProfile(c, LoadProfile{Steps: []LoadStep{{5, 300}, {55, 10}}})
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		if result.Drop.MetricSize != 35 || result.Size != 50 {
+			t.Errorf("drop size %v, size %v, want 35 and 50", result.Drop.MetricSize, result.Size)
+		}
+		if valid, _ := ValidateWireTemperature(result.ConductorTemp, wireTypes["generic"]); !valid {
+			t.Errorf("ConductorTemp %.1f°C exceeds the rating", result.ConductorTemp)
+		}
+	})
+
+	t.Run("invalid circuit", func(t *testing.T) {
+		c := c
+		c.Voltage = 0
+		if _, err := sizeForLoadProfile(c, dutyCycleProfile(100, 10, 10, 60)); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
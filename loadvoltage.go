@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Minimum load voltage
+//
+// Instead of a percentage drop, the allowed drop can be derived from the
+// minimum voltage a load needs, taking the sag of the source under load
+// into account. The derived percentage is passed on to calculateCableArea.
+
+// Terminal voltage of a source under load.
+//
+// Formula: V_terminal = V - I × R_source
+func terminalVoltage(voltage, current, sourceResistance float64) float64 {
+	return voltage - current*sourceResistance
+}
+
+// Calculate the allowed voltage drop (%) for a minimum load voltage.
+//
+// Formula: drop% = (V_terminal - V_min) / V × 100
+//
+// The percentage refers to the nominal source voltage V, as used by
+// calculateCableArea. Returns an error if the terminal voltage does not
+// exceed the minimum load voltage.
+func allowedDropForMinVoltage(voltage, current, sourceResistance, minLoadVoltage float64) (float64, error) {
+	terminal := terminalVoltage(voltage, current, sourceResistance)
+	if terminal <= minLoadVoltage {
+		return 0, fmt.Errorf("source voltage under load (%.2f V) does not exceed the minimum load voltage (%.2f V)", terminal, minLoadVoltage)
+	}
+	return (terminal - minLoadVoltage) / voltage * 100, nil
+}
+
+// Parse a minimum load voltage such as "11V" or "11.5 v".
+//
+// Returns false if the input has no V suffix.
+func parseMinLoadVoltage(s string) (float64, bool, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if !strings.HasSuffix(s, "v") {
+		return 0, false, nil
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "v")), 64)
+	if err != nil || v <= 0 {
+		return 0, true, fmt.Errorf("invalid minimum load voltage %q", s)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAllowedDropForMinVoltage(t *testing.T) {
+	tests := []struct {
+		name             string
+		voltage          float64
+		current          float64
+		sourceResistance float64
+		minLoadVoltage   float64
+		want             float64
+		wantErr          bool
+	}{
+		{"ideal source", 12, 20, 0, 11, 8.333, false},
+		{"source sag", 12, 20, 0.01, 11, 6.667, false}, // 11.8 V under load
+		{"24V", 24, 50, 0.02, 22, 4.167, false},
+		{"sag below minimum", 12, 100, 0.02, 11, 0, true}, // 10 V under load
+		{"minimum equals terminal", 12, 10, 0.1, 11, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allowedDropForMinVoltage(tt.voltage, tt.current, tt.sourceResistance, tt.minLoadVoltage)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("got %.3f%%, want %.3f%%", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMinLoadVoltage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		isMin   bool
+		wantErr bool
+	}{
+		{"11V", 11, true, false},
+		{" 11.5 v ", 11.5, true, false},
+		{"3", 0, false, false},
+		{"", 0, false, false},
+		{"xV", 0, true, true},
+		{"-1V", 0, true, true},
+	}
+	for _, tt := range tests {
+		got, isMin, err := parseMinLoadVoltage(tt.input)
+		if (err != nil) != tt.wantErr || isMin != tt.isMin || got != tt.want {
+			t.Errorf("parseMinLoadVoltage(%q) = %v, %v, %v, want %v, %v, err %v", tt.input, got, isMin, err, tt.want, tt.isMin, tt.wantErr)
+		}
+	}
+}
+
+func TestCircuitMinLoadVoltage(t *testing.T) {
+	c := testCircuit("load")
+	c.Current = 20
+	c.MinLoadVoltage = 11
+	c.SourceResistance = 0.01
+	c.MaxVoltageDropPercent = 0 // Ignored in minimum load voltage mode
+
+	if err := c.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	if got, _ := c.maxDropPercent(); math.Abs(got-6.667) > 0.001 {
+		t.Errorf("maxDropPercent() = %.3f, want 6.667", got)
+	}
+	if c.MaxVoltageDropPercent != 0 {
+		t.Errorf("MaxVoltageDropPercent = %v, want the input 0 unchanged", c.MaxVoltageDropPercent)
+	}
+	material, _, _, _ := c.resolve()
+	want := calculateCableArea(c.Voltage, c.Current, c.Length, 0.8/12*100, material, c.RoundTrip, c.AmbientTempCelsius, c.Installation)
+	if math.Abs(c.RequiredArea-want) > 1e-9 {
+		t.Errorf("RequiredArea = %v, want %v", c.RequiredArea, want)
+	}
+
+	c.MinLoadVoltage = 12
+	if err := c.recalculate(); err == nil {
+		t.Error("expected error for minimum load voltage at system voltage")
+	}
+	c.MinLoadVoltage = 11.9
+	if err := c.recalculate(); err == nil {
+		t.Error("expected error for minimum load voltage above the sagging source")
+	}
+}
@@ -37,90 +37,156 @@ const (
 
 	// Reference temperature for resistivity values (°C)
 	referenceTemp = 20.0
+
+	// Density of copper (kg/m³)
+	copperDensity = 8960.0
+
+	// Density of aluminum (kg/m³)
+	aluminumDensity = 2700.0
+
+	// Typical conductor price of copper (currency units per kg)
+	copperPricePerKg = 9.0
+
+	// Typical conductor price of aluminum (currency units per kg)
+	aluminumPricePerKg = 2.5
+
+	// Specific heat capacity of copper (J/(kg·K))
+	copperSpecificHeat = 385.0
+
+	// Specific heat capacity of aluminum (J/(kg·K))
+	aluminumSpecificHeat = 897.0
 )
 
 type CableMaterial struct {
-	Name            string
-	Resistivity20C  float64
-	TempCoefficient float64
+	Name            string  `json:"name"`
+	Resistivity20C  float64 `json:"resistivity_20c"`
+	TempCoefficient float64 `json:"temp_coefficient"`
+	Density         float64 `json:"density"`       // kg/m³
+	PricePerKg      float64 `json:"price_per_kg"`  // Currency units per kg of conductor
+	SpecificHeat    float64 `json:"specific_heat"` // J/(kg·K)
 }
 
 var materials = map[string]CableMaterial{
-	"copper":   {"Copper", copperResistivity20C, copperTempCoefficient},
-	"aluminum": {"Aluminum", aluminumResistivity20C, aluminumTempCoefficient},
+	"copper":   {"Copper", copperResistivity20C, copperTempCoefficient, copperDensity, copperPricePerKg, copperSpecificHeat},
+	"aluminum": {"Aluminum", aluminumResistivity20C, aluminumTempCoefficient, aluminumDensity, aluminumPricePerKg, aluminumSpecificHeat},
 }
 
 // InstallationMethod represents how the cable is installed
 type InstallationMethod string
 
 const (
-	InstallationInAir    InstallationMethod = "air"
-	InstallationConduit  InstallationMethod = "conduit"
-	InstallationIsolated InstallationMethod = "isolated"
+	InstallationInAir      InstallationMethod = "air"
+	InstallationConduit    InstallationMethod = "conduit"
+	InstallationIsolated   InstallationMethod = "isolated"
+	InstallationBuried     InstallationMethod = "buried" // Directly in the ground
+	InstallationBuriedDuct InstallationMethod = "duct"   // In ducts in the ground
 )
 
 // Temperature adjustment factors for installation methods
 // These represent the temperature rise above ambient due to installation method
 // Values are approximate temperature increases in °C
 var installationTempAdjustments = map[InstallationMethod]float64{
-	InstallationInAir:    0.0,  // Good cooling, minimal temperature rise
-	InstallationConduit:  10.0, // Reduced cooling, moderate temperature rise
-	InstallationIsolated: 20.0, // Poor cooling, significant temperature rise
+	InstallationInAir:      0.0,  // Good cooling, minimal temperature rise
+	InstallationConduit:    10.0, // Reduced cooling, moderate temperature rise
+	InstallationIsolated:   20.0, // Poor cooling, significant temperature rise
+	InstallationBuried:     10.0, // Soil at reference conditions (2.5 K·m/W, 0.7 m)
+	InstallationBuriedDuct: 15.0, // Air gap between cable and duct wall
 }
 
 // WireType represents the wire/cable type with its maximum temperature rating
 type WireType struct {
-	Name           string
-	MaxTempCelsius float64
-	Description    string
+	Name                string
+	MaxTempCelsius      float64
+	Description         string
+	InsulationThickness float64 // Nominal radial insulation thickness (mm)
+	Stranding           int     // Default IEC 60228 conductor class (1, 2, 5 or 6)
 }
 
 // Common wire types with their maximum operating temperatures
 var wireTypes = map[string]WireType{
 	"flry": {
-		Name:           "FLRY",
-		MaxTempCelsius: 105.0,
-		Description:    "Automotive thin-wall PVC (FLRY-A/B), stranded copper",
+		Name:                "FLRY",
+		MaxTempCelsius:      105.0,
+		Description:         "Automotive thin-wall PVC (FLRY-A/B), stranded copper",
+		InsulationThickness: 0.3,
+		Stranding:           5,
 	},
 	"flry-a": {
-		Name:           "FLRY-A",
-		MaxTempCelsius: 105.0,
-		Description:    "Automotive thin-wall PVC, flexible stranded",
+		Name:                "FLRY-A",
+		MaxTempCelsius:      105.0,
+		Description:         "Automotive thin-wall PVC, flexible stranded",
+		InsulationThickness: 0.3,
+		Stranding:           5,
 	},
 	"flry-b": {
-		Name:           "FLRY-B",
-		MaxTempCelsius: 105.0,
-		Description:    "Automotive thin-wall PVC, symmetrical stranded",
+		Name:                "FLRY-B",
+		MaxTempCelsius:      105.0,
+		Description:         "Automotive thin-wall PVC, symmetrical stranded",
+		InsulationThickness: 0.3,
+		Stranding:           2,
+	},
+	"gxl": {
+		Name:                "GXL",
+		MaxTempCelsius:      125.0,
+		Description:         "SAE J1128 automotive thin-wall cross-linked polyethylene",
+		InsulationThickness: 0.6,
+		Stranding:           5,
+	},
+	"txl": {
+		Name:                "TXL",
+		MaxTempCelsius:      125.0,
+		Description:         "SAE J1128 automotive extra-thin-wall cross-linked polyethylene",
+		InsulationThickness: 0.4,
+		Stranding:           5,
+	},
+	"sxl": {
+		Name:                "SXL",
+		MaxTempCelsius:      125.0,
+		Description:         "SAE J1128 automotive standard-wall cross-linked polyethylene",
+		InsulationThickness: 0.9,
+		Stranding:           5,
 	},
 	"thhn": {
-		Name:           "THHN",
-		MaxTempCelsius: 90.0,
-		Description:    "Thermoplastic, high heat, nylon coated",
+		Name:                "THHN",
+		MaxTempCelsius:      90.0,
+		Description:         "Thermoplastic, high heat, nylon coated",
+		InsulationThickness: 0.5,
+		Stranding:           2,
 	},
 	"thwn": {
-		Name:           "THWN",
-		MaxTempCelsius: 75.0,
-		Description:    "Thermoplastic, heat/water resistant, nylon coated",
+		Name:                "THWN",
+		MaxTempCelsius:      75.0,
+		Description:         "Thermoplastic, heat/water resistant, nylon coated",
+		InsulationThickness: 0.5,
+		Stranding:           2,
 	},
 	"xlpe": {
-		Name:           "XLPE",
-		MaxTempCelsius: 90.0,
-		Description:    "Cross-linked polyethylene insulation",
+		Name:                "XLPE",
+		MaxTempCelsius:      90.0,
+		Description:         "Cross-linked polyethylene insulation",
+		InsulationThickness: 0.7,
+		Stranding:           2,
 	},
 	"pvc": {
-		Name:           "PVC",
-		MaxTempCelsius: 70.0,
-		Description:    "Standard PVC insulation",
+		Name:                "PVC",
+		MaxTempCelsius:      70.0,
+		Description:         "Standard PVC insulation",
+		InsulationThickness: 0.8,
+		Stranding:           5,
 	},
 	"silicon": {
-		Name:           "Silicone",
-		MaxTempCelsius: 200.0,
-		Description:    "Silicone rubber insulation, high temperature",
+		Name:                "Silicone",
+		MaxTempCelsius:      200.0,
+		Description:         "Silicone rubber insulation, high temperature",
+		InsulationThickness: 1.0,
+		Stranding:           6,
 	},
 	"generic": {
-		Name:           "Generic",
-		MaxTempCelsius: 90.0,
-		Description:    "Generic wire type (assumes 90°C rating)",
+		Name:                "Generic",
+		MaxTempCelsius:      90.0,
+		Description:         "Generic wire type (assumes 90°C rating)",
+		InsulationThickness: 0.8,
+		Stranding:           5,
 	},
 }
 
@@ -130,29 +196,92 @@ type AWGSize struct {
 	Area  float64
 }
 
-// Standard AWG to mm² conversion (common sizes)
-var awgSizes = []AWGSize{
-	{Label: "18", Area: 0.823},
-	{Label: "16", Area: 1.309},
-	{Label: "14", Area: 2.081},
-	{Label: "12", Area: 3.309},
-	{Label: "10", Area: 5.261},
-	{Label: "8", Area: 8.367},
-	{Label: "6", Area: 13.30},
-	{Label: "4", Area: 21.15},
-	{Label: "2", Area: 33.62},
-	{Label: "1", Area: 42.41},
-	{Label: "1/0", Area: 53.49},
-	{Label: "2/0", Area: 67.43},
-	{Label: "3/0", Area: 85.01},
-	{Label: "4/0", Area: 107.2},
-}
+// Standard AWG to mm² conversion, 40 AWG to 4/0 followed by kcmil sizes
+// up to 1000 kcmil. See buildAWGSizes.
+var awgSizes = buildAWGSizes()
+
+// Large conductor sizes in kcmil (thousands of circular mils)
+var kcmilSizes = []float64{250, 300, 350, 400, 500, 600, 700, 750, 800, 900, 1000}
+
+const (
+	// Diameter of 36 AWG (mm), the reference point of the AWG formula
+	awgReferenceDiameter = 0.127
+
+	// Area of one kcmil (mm²): 1000 × π/4 × (0.0254 mm)²
+	mm2PerKcmil = 0.5067074790975
+)
 
 // Standard metric cable sizes (mm²)
 var standardMetricSizes = []float64{
 	0.5, 0.75, 1.0, 1.5, 2.5, 4.0, 6.0, 10.0, 16.0, 25.0, 35.0, 50.0, 70.0, 95.0, 120.0, 150.0, 185.0, 240.0,
 }
 
+// CableProfile restricts size recommendations to a particular series
+// of conductor sizes.
+type CableProfile struct {
+	Name        string
+	MetricSizes []float64
+	AWGSizes    []AWGSize
+}
+
+// Available cable profiles
+var cableProfiles = map[string]CableProfile{
+	"standard": {
+		Name:        "Standard (IEC 60228 / AWG)",
+		MetricSizes: standardMetricSizes,
+		AWGSizes:    awgSizes,
+	},
+	"automotive": {
+		Name:        "Automotive (ISO 6722 / SAE J1128)",
+		MetricSizes: iso6722Sizes,
+		AWGSizes:    saeJ1128AWGSizes,
+	},
+}
+
+// Calculate the cross-sectional area of an AWG gauge.
+//
+// Formula: d = 0.127 mm × 92^((36 - n) / 39), A = π/4 × d²
+// Where n is the gauge number; 1/0, 2/0, 3/0 and 4/0 are n = 0, -1, -2, -3.
+func awgGaugeArea(gauge int) float64 {
+	diameter := awgReferenceDiameter * math.Pow(92, float64(36-gauge)/39)
+	return math.Pi / 4 * diameter * diameter
+}
+
+// Label of an AWG gauge number (e.g. "12", "1/0", "4/0").
+func awgLabel(gauge int) string {
+	if gauge > 0 {
+		return strconv.Itoa(gauge)
+	}
+	return strconv.Itoa(1-gauge) + "/0"
+}
+
+// Build the AWG size table from the AWG formula.
+//
+// Covers 40 AWG to 4/0 followed by the kcmil sizes. Areas are rounded to
+// four significant figures as in published AWG tables.
+func buildAWGSizes() []AWGSize {
+	var sizes []AWGSize
+	for gauge := 40; gauge >= -3; gauge-- {
+		sizes = append(sizes, AWGSize{Label: awgLabel(gauge), Area: roundSignificant(awgGaugeArea(gauge), 4)})
+	}
+	for _, kcmil := range kcmilSizes {
+		sizes = append(sizes, AWGSize{
+			Label: strconv.FormatFloat(kcmil, 'f', -1, 64) + " kcmil",
+			Area:  roundSignificant(kcmil*mm2PerKcmil, 4),
+		})
+	}
+	return sizes
+}
+
+// Round a value to the given number of significant figures.
+func roundSignificant(value float64, digits int) float64 {
+	if value == 0 {
+		return 0
+	}
+	scale := math.Pow(10, float64(digits)-math.Ceil(math.Log10(math.Abs(value))))
+	return math.Round(value*scale) / scale
+}
+
 // Calculate resistivity at given temperature.
 //
 // Formula: ρ(T) = ρ(20°C) × [1 + α × (T - 20)]
@@ -167,16 +296,6 @@ func calculateResistivityAtTemp(material CableMaterial, tempCelsius float64) flo
 	return material.Resistivity20C * (1 + material.TempCoefficient*(tempCelsius-referenceTemp))
 }
 
-// Convert Fahrenheit to Celsius
-func fahrenheitToCelsius(f float64) float64 {
-	return (f - 32) * 5 / 9
-}
-
-// Convert Celsius to Fahrenheit
-func celsiusToFahrenheit(c float64) float64 {
-	return c*9/5 + 32
-}
-
 // Calculate effective operating temperature considering installation method.
 //
 // The effective temperature accounts for ambient temperature plus
@@ -191,17 +310,13 @@ func calculateEffectiveTemp(ambientTempCelsius float64, installation Installatio
 //
 // Returns true if temperature is within limits, false if exceeded.
 // Also returns a warning message if temperature is close to the limit (>90% of max).
+// This wraps checkWireTemperature, which reports the same check as a diagnostic.
 func ValidateWireTemperature(effectiveTempCelsius float64, wireType WireType) (bool, string) {
-	if effectiveTempCelsius > wireType.MaxTempCelsius {
-		return false, fmt.Sprintf("WARNING: Effective operating temperature (%.1f°C) exceeds %s maximum rating (%.0f°C)! Wire insulation may fail.", effectiveTempCelsius, wireType.Name, wireType.MaxTempCelsius)
-	}
-
-	// Warn if within 10% of maximum
-	if effectiveTempCelsius > wireType.MaxTempCelsius*0.9 {
-		return true, fmt.Sprintf("CAUTION: Effective operating temperature (%.1f°C) is close to %s maximum rating (%.0f°C). Consider using a higher temperature rated wire.", effectiveTempCelsius, wireType.Name, wireType.MaxTempCelsius)
+	d := checkWireTemperature(effectiveTempCelsius, wireType)
+	if d == nil {
+		return true, ""
 	}
-
-	return true, ""
+	return d.Severity != SeverityError, d.Message
 }
 
 // Calculate required cross-sectional area based on voltage drop.
@@ -257,10 +372,18 @@ func areaToDiameter(area float64) float64 {
 // Standard sizes: 0.5, 0.75, 1.0, 1.5, 2.5, 4.0, 6.0, 10.0, 16.0, 25.0,
 // 35.0, 50.0, 70.0, 95.0, 120.0, 150.0, 185.0, 240.0 mm²
 func findClosestMetricSize(requiredArea float64) (float64, float64) {
+	return findClosestSizeIn(standardMetricSizes, requiredArea)
+}
+
+// Find closest size within a given series of metric sizes.
+//
+// Used by findClosestMetricSize and by cable profiles that restrict
+// recommendations to their own size series.
+func findClosestSizeIn(sizes []float64, requiredArea float64) (float64, float64) {
 	var closestSize float64
 	minDiff := math.MaxFloat64
 
-	for _, size := range standardMetricSizes {
+	for _, size := range sizes {
 		diff := math.Abs(size - requiredArea)
 		if diff < minDiff {
 			minDiff = diff
@@ -276,13 +399,19 @@ func findClosestMetricSize(requiredArea float64) (float64, float64) {
 // Returns the AWG label (e.g., "12", "1/0", "2/0"), the cross-sectional
 // area of that AWG size, and the absolute difference from the required area.
 //
-// Supported AWG sizes: 18, 16, 14, 12, 10, 8, 6, 4, 2, 1, 1/0, 2/0, 3/0, 4/0
+// Supported AWG sizes: 40 AWG to 4/0 and 250 to 1000 kcmil.
+// Use awgRangeDiagnostic to detect areas above the supported range.
 func findClosestAWG(requiredArea float64) (string, float64, float64) {
+	return findClosestAWGIn(awgSizes, requiredArea)
+}
+
+// Find closest AWG size within a given series of AWG sizes.
+func findClosestAWGIn(sizes []AWGSize, requiredArea float64) (string, float64, float64) {
 	var closestLabel string
 	var closestArea float64
 	minDiff := math.MaxFloat64
 
-	for _, awg := range awgSizes {
+	for _, awg := range sizes {
 		diff := math.Abs(awg.Area - requiredArea)
 		if diff < minDiff {
 			minDiff = diff
@@ -294,9 +423,42 @@ func findClosestAWG(requiredArea float64) (string, float64, float64) {
 	return closestLabel, closestArea, minDiff
 }
 
+// Find the smallest size of a metric series that is at least the required
+// area.
+//
+// Unlike findClosestSizeIn this never returns an undersized conductor.
+// Returns the largest size if none is large enough.
+func findSmallestSizeIn(sizes []float64, requiredArea float64) float64 {
+	for _, size := range sizes {
+		if size >= requiredArea {
+			return size
+		}
+	}
+	return sizes[len(sizes)-1]
+}
+
+// Find the smallest AWG size of a series that is at least the required
+// area.
+//
+// Returns the label and area of the size, or of the largest size if none
+// is large enough.
+func findSmallestAWGIn(sizes []AWGSize, requiredArea float64) (string, float64) {
+	for _, awg := range sizes {
+		if awg.Area >= requiredArea {
+			return awg.Label, awg.Area
+		}
+	}
+	largest := sizes[len(sizes)-1]
+	return largest.Label, largest.Area
+}
+
 func main() {
 	reader := bufio.NewReader(os.Stdin)
 
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1], os.Args[2:], reader))
+	}
+
 	fmt.Println("=== DC Cable Diameter Calculator ===")
 	fmt.Println("Supports 12V, 24V, 48V, 50V DC systems")
 	fmt.Println()
@@ -322,21 +484,37 @@ func main() {
 	}
 
 	// Get cable length
-	fmt.Print("Enter cable length (m): ")
+	fmt.Print("Enter cable length (m, or add 'ft' for feet): ")
 	lengthStr, _ := reader.ReadString('\n')
-	lengthStr = strings.TrimSpace(lengthStr)
-	length, err := strconv.ParseFloat(lengthStr, 64)
+	length, err := parseLength(lengthStr, LengthMeters)
 	if err != nil || length <= 0 {
 		fmt.Println("Error: Invalid length. Please enter a positive value.")
 		return
 	}
 
-	// Get voltage drop percentage
-	fmt.Print("Enter maximum voltage drop percentage (default 3%): ")
+	// Get voltage drop percentage, or minimum load voltage
+	fmt.Print("Enter maximum voltage drop percentage (default 3%), or minimum load voltage (e.g. 11V): ")
 	dropStr, _ := reader.ReadString('\n')
 	dropStr = strings.TrimSpace(dropStr)
 	maxVoltageDropPercent := 3.0
-	if dropStr != "" {
+	var sourceResistance float64
+	minLoadVoltage, isMinVoltage, err := parseMinLoadVoltage(dropStr)
+	if isMinVoltage {
+		if err != nil || minLoadVoltage >= voltage {
+			fmt.Println("Error: Invalid minimum load voltage. Please enter a value below the system voltage.")
+			return
+		}
+		sourceResistance, err = promptFloat(reader, "Enter source internal resistance (Ω, default 0): ", 0)
+		if err != nil || sourceResistance < 0 {
+			fmt.Println("Error: Invalid source resistance. Please enter a non-negative value.")
+			return
+		}
+		maxVoltageDropPercent, err = allowedDropForMinVoltage(voltage, current, sourceResistance, minLoadVoltage)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+	} else if dropStr != "" {
 		maxVoltageDropPercent, err = strconv.ParseFloat(dropStr, 64)
 		if err != nil || maxVoltageDropPercent <= 0 || maxVoltageDropPercent > 10 {
 			fmt.Println("Warning: Invalid voltage drop percentage. Using default 3%.")
@@ -344,6 +522,14 @@ func main() {
 		}
 	}
 
+	// Get series components
+	components, err := parseComponents(promptString(reader, "Series components, e.g. crimp:4,blade-fuse (Enter for none): "))
+	if err != nil {
+		fmt.Printf("Warning: %v. Ignoring series components.\n", err)
+		components = nil
+	}
+	componentResistance, _ := componentsResistance(components)
+
 	// Get round trip option
 	fmt.Print("Is this round trip length? (y/n, default: n): ")
 	roundTripStr, _ := reader.ReadString('\n')
@@ -388,82 +574,242 @@ func main() {
 	}
 
 	// Get installation method
-	fmt.Print("Installation method (air/conduit/isolated, default: air): ")
-	installStr, _ := reader.ReadString('\n')
-	installStr = strings.TrimSpace(strings.ToLower(installStr))
-	var installation InstallationMethod
-	switch installStr {
-	case "conduit":
-		installation = InstallationConduit
-	case "isolated":
-		installation = InstallationIsolated
-	case "air", "":
-		installation = InstallationInAir
-	default:
-		installation = InstallationInAir
-		fmt.Println("Using default: In air")
+	installation := promptInstallation(reader)
+
+	// Get the soil conditions of buried cables, or altitude and solar radiation
+	var altitude, irradiance float64
+	soilResistivity, burialDepth := referenceSoilResistivity, referenceBurialDepth
+	if isBuried(installation) {
+		groundTemp, err := promptFloat(reader, fmt.Sprintf("Ground temperature at burial depth in °%s (default: %.1f): ", tempUnitStr, ambientTemp), ambientTemp)
+		if err != nil {
+			fmt.Println("Using default: ambient temperature")
+			groundTemp = ambientTemp
+		}
+		ambientTemp, ambientTempCelsius = groundTemp, groundTemp
+		if tempUnitStr == "F" {
+			ambientTempCelsius = fahrenheitToCelsius(groundTemp)
+		}
+		soilResistivity, err = promptFloat(reader, "Soil thermal resistivity in K·m/W (default: 2.5): ", referenceSoilResistivity)
+		if err != nil {
+			fmt.Println("Using default: 2.5 K·m/W")
+			soilResistivity = referenceSoilResistivity
+		}
+		burialDepth, err = promptFloat(reader, "Burial depth in m (default: 0.7): ", referenceBurialDepth)
+		if err != nil {
+			fmt.Println("Using default: 0.7 m")
+			burialDepth = referenceBurialDepth
+		}
+	} else {
+		altitude, err = promptFloat(reader, "Altitude above sea level in m (default: 0): ", 0)
+		if err != nil || altitude < 0 {
+			fmt.Println("Using default: 0 m")
+			altitude = 0
+		}
+		if installation == InstallationInAir {
+			irradiance, err = promptFloat(reader, "Direct solar irradiance in W/m², e.g. 1000 in full sun (default: 0, shaded): ", 0)
+			if err != nil || irradiance < 0 || irradiance > maxSolarIrradiance {
+				fmt.Println("Using default: shaded")
+				irradiance = 0
+			}
+		}
+	}
+	soilDepthFactor, err := soilCorrectionFactor(installation, soilResistivity, burialDepth)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
 
+	// Get cable profile
+	fmt.Print("Cable profile (standard/automotive, default: standard): ")
+	profileStr, _ := reader.ReadString('\n')
+	profileStr = strings.TrimSpace(strings.ToLower(profileStr))
+	profile, ok := cableProfiles[profileStr]
+	if !ok {
+		profile = cableProfiles["standard"]
+		if profileStr != "" {
+			fmt.Println("Using default: Standard")
+		}
+	}
+	automotive := profileStr == "automotive"
+
 	// Get wire type
-	fmt.Print("Wire type (flry/flry-a/flry-b/thhn/thwn/xlpe/pvc/silicon/generic, default: generic): ")
-	wireTypeStr, _ := reader.ReadString('\n')
-	wireTypeStr = strings.TrimSpace(strings.ToLower(wireTypeStr))
-	wireType, ok := wireTypes[wireTypeStr]
+	var wireType WireType
+	var wireTypeKey string
+	var wall InsulationWall
+	if automotive {
+		fmt.Print("Insulation wall (thin/thick, default: thin): ")
+		wallStr, _ := reader.ReadString('\n')
+		wall = WallThin
+		if strings.TrimSpace(strings.ToLower(wallStr)) == "thick" {
+			wall = WallThick
+		}
+
+		fmt.Print("ISO 6722 temperature class (a-e) or SAE J1128 type (gxl/txl/sxl), default: b): ")
+		classStr, _ := reader.ReadString('\n')
+		classStr = strings.TrimSpace(strings.ToLower(classStr))
+		wireType, wall, ok = lookupAutomotiveWireType(classStr, wall)
+		wireTypeKey = classStr
+		if !ok {
+			wireType, wireTypeKey = iso6722TempClasses["b"], "b"
+			fmt.Println("Using default: ISO 6722 Class B (100°C)")
+		}
+	} else {
+		fmt.Print("Wire type (flry/flry-a/flry-b/gxl/txl/sxl/thhn/thwn/xlpe/pvc/silicon/generic, default: generic): ")
+		wireTypeStr, _ := reader.ReadString('\n')
+		wireTypeStr = strings.TrimSpace(strings.ToLower(wireTypeStr))
+		wireType, ok = wireTypes[wireTypeStr]
+		wireTypeKey = wireTypeStr
+		if !ok {
+			wireType, wireTypeKey = wireTypes["generic"], "generic"
+			fmt.Println("Using default: Generic (90°C)")
+		}
+	}
+
+	// Get stranding class
+	fmt.Printf("Stranding class (IEC 60228 1/2/5/6, default: %d): ", wireType.Stranding)
+	strandingStr, _ := reader.ReadString('\n')
+	strandingStr = strings.TrimSpace(strandingStr)
+	if strandingStr != "" {
+		class, err := strconv.Atoi(strandingStr)
+		if _, lookupErr := lookupStrandingClass(class); err != nil || lookupErr != nil {
+			fmt.Printf("Using default: class %d\n", wireType.Stranding)
+		} else {
+			wireType.Stranding = class
+		}
+	}
+	stranding := strandingClasses[wireType.Stranding]
+
+	if err := checkGroundTemp(installation, ambientTempCelsius, wireType.MaxTempCelsius); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	correctionRise := environmentTempRise(installation, altitude, irradiance) + soilTempRise(installation, soilDepthFactor)
+
+	// Get optional fixed conductor size to check
+	fmt.Print("Fixed conductor size to check (e.g. 16mm2, 6 AWG, 250 kcmil; empty to skip): ")
+	fixedStr, _ := reader.ReadString('\n')
+	fixedStr = strings.TrimSpace(fixedStr)
+	var fixedArea float64
+	var fixedLabel string
+	if fixedStr != "" {
+		fixedArea, fixedLabel, err = parseConductorSize(fixedStr)
+		if err != nil {
+			fmt.Printf("Warning: %v. Skipping fixed size check.\n", err)
+			fixedArea = 0
+		}
+	}
+
+	// Get output units
+	fmt.Print("Output units (metric/imperial/both, default: metric): ")
+	unitsStr, _ := reader.ReadString('\n')
+	units, ok := parseDisplayUnits(unitsStr)
 	if !ok {
-		wireType = wireTypes["generic"]
-		fmt.Println("Using default: Generic (90°C)")
+		fmt.Println("Using default: Metric")
 	}
 
 	fmt.Println()
 	fmt.Println("=== Calculation Results ===")
 	fmt.Printf("System Voltage: %.1f V\n", voltage)
 	fmt.Printf("Current: %.2f A\n", current)
-	fmt.Printf("Cable Length: %.2f m (%s)\n", length, map[bool]string{true: "round trip", false: "one-way"}[roundTrip])
+	fmt.Printf("Cable Length: %s (%s)\n", formatLength(length, units), map[bool]string{true: "round trip", false: "one-way"}[roundTrip])
 	fmt.Printf("Material: %s\n", material.Name)
+	fmt.Printf("Cable Profile: %s\n", profile.Name)
 	fmt.Printf("Wire Type: %s (Max: %.0f°C) - %s\n", wireType.Name, wireType.MaxTempCelsius, wireType.Description)
-	fmt.Printf("Ambient Temperature: %.1f°%s (%.1f°C)\n", ambientTemp, tempUnitStr, ambientTempCelsius)
+	if automotive {
+		fmt.Printf("Insulation Wall: %s\n", wall)
+	}
+	if isBuried(installation) {
+		fmt.Printf("Ground Temperature: %.1f°%s (%.1f°C)\n", ambientTemp, tempUnitStr, ambientTempCelsius)
+	} else {
+		fmt.Printf("Ambient Temperature: %.1f°%s (%.1f°C)\n", ambientTemp, tempUnitStr, ambientTempCelsius)
+	}
 	fmt.Printf("Installation Method: %s\n", map[InstallationMethod]string{
-		InstallationInAir:    "In air",
-		InstallationConduit:  "In conduit",
-		InstallationIsolated: "Isolated/Insulated",
+		InstallationInAir:      "In air",
+		InstallationConduit:    "In conduit",
+		InstallationIsolated:   "Isolated/Insulated",
+		InstallationBuried:     "Buried directly in ground",
+		InstallationBuriedDuct: "In ducts in ground",
 	}[installation])
+	if isBuried(installation) {
+		fmt.Printf("Soil: %.2f K·m/W, depth %.2f m\n", soilResistivity, burialDepth)
+		fmt.Printf("Current Rating Factor: %.2f (soil and depth)\n", soilDepthFactor)
+	}
+	if altitude > 0 {
+		fmt.Printf("Altitude: %.0f m (air density %.0f%%)\n", altitude, airDensityRatio(altitude)*100)
+	}
+	if irradiance > 0 {
+		fmt.Printf("Solar Irradiance: %.0f W/m² (+%.1f K)\n", irradiance, solarTempRise(irradiance, installation)*altitudeCorrectionFactor(altitude))
+	}
 
-	effectiveTemp := calculateEffectiveTemp(ambientTempCelsius, installation)
-	fmt.Printf("Effective Operating Temperature: %.1f°C\n", effectiveTemp)
+	effectiveTemp := calculateCorrectedEffectiveTemp(ambientTempCelsius, installation, altitude, irradiance) + soilTempRise(installation, soilDepthFactor)
+	fmt.Printf("Effective Operating Temperature: %s\n", formatTemp(effectiveTemp, units))
 
 	// Validate wire temperature rating
-	isValid, warningMsg := ValidateWireTemperature(effectiveTemp, wireType)
-	if !isValid {
-		fmt.Println()
-		fmt.Println("⚠️  " + warningMsg)
-		fmt.Println("   The calculated cable size may not be safe for this wire type!")
-		fmt.Println("   Consider: using a higher temperature rated wire, reducing ambient temperature,")
-		fmt.Println("   improving cooling, or increasing cable size to reduce heat generation.")
-		fmt.Println()
-	} else if warningMsg != "" {
+	if d := checkWireTemperature(effectiveTemp, wireType); d != nil {
 		fmt.Println()
-		fmt.Println("⚠️  " + warningMsg)
+		printDiagnostic(os.Stdout, d)
+		if d.Severity == SeverityError {
+			fmt.Println("   The calculated cable size may not be safe for this wire type!")
+			fmt.Println("   Consider: using a higher temperature rated wire, reducing ambient temperature,")
+			fmt.Println("   improving cooling, or increasing cable size to reduce heat generation.")
+		}
 		fmt.Println()
 	}
 
+	if isMinVoltage {
+		fmt.Printf("Minimum Load Voltage: %.2f V (source under load: %.2f V)\n", minLoadVoltage, terminalVoltage(voltage, current, sourceResistance))
+	}
 	fmt.Printf("Maximum Voltage Drop: %.2f%% (%.2f V)\n", maxVoltageDropPercent, voltage*maxVoltageDropPercent/100)
+	conductorDropPercent, err := conductorDropBudget(voltage, current, maxVoltageDropPercent, componentResistance)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	componentDrop := current * componentResistance
+	if len(components) > 0 {
+		fmt.Printf("Series Components: %s (%.2f mΩ, %.3f V)\n", strings.Join(components, ", "), componentResistance*1000, componentDrop)
+		fmt.Printf("Conductor Voltage Drop Budget: %.2f%% (%.2f V)\n", conductorDropPercent, voltage*conductorDropPercent/100)
+	}
 	fmt.Println()
 
 	// Calculate required area
-	requiredArea := calculateCableArea(voltage, current, length, maxVoltageDropPercent, material, roundTrip, ambientTempCelsius, installation)
+	requiredArea := calculateCableArea(voltage, current, length, conductorDropPercent, material, roundTrip, ambientTempCelsius+correctionRise, installation)
 	requiredDiameter := areaToDiameter(requiredArea)
 
-	fmt.Printf("Required Cross-Sectional Area: %.2f mm²\n", requiredArea)
-	fmt.Printf("Required Diameter: %.2f mm\n", requiredDiameter)
+	fmt.Printf("Required Cross-Sectional Area: %s\n", formatArea(requiredArea, units))
+	fmt.Printf("Required Diameter: %s\n", formatDiameter(requiredDiameter, units))
 	fmt.Println()
 
-	// Find standard sizes
-	closestMetric, metricDiff := findClosestMetricSize(requiredArea)
-	closestAWG, awgArea, awgDiff := findClosestAWG(requiredArea)
+	// Find the smallest standard sizes that are large enough
+	recommendedMetric := findSmallestSizeIn(profile.MetricSizes, requiredArea)
+	metricDiff := math.Abs(recommendedMetric - requiredArea)
+	recommendedAWG, awgArea := findSmallestAWGIn(profile.AWGSizes, requiredArea)
+	awgDiff := math.Abs(awgArea - requiredArea)
+
+	outOfRange := checkOutOfRange(profile.MetricSizes, requiredArea, voltage)
 
 	fmt.Println("=== Recommended Standard Sizes ===")
-	fmt.Printf("Metric: %.2f mm² (difference: %.2f mm²)\n", closestMetric, metricDiff)
-	fmt.Printf("AWG: %s (%.2f mm², difference: %.2f mm²)\n", closestAWG, awgArea, awgDiff)
+	if outOfRange != nil {
+		printDiagnostic(os.Stdout, outOfRangeDiagnostic(outOfRange))
+	} else {
+		fmt.Printf("Metric: %s (difference: %s)\n", formatArea(recommendedMetric, units), formatArea(metricDiff, units))
+	}
+	fmt.Printf("AWG: %s (%s, difference: %s)\n", recommendedAWG, formatArea(awgArea, units), formatArea(awgDiff, units))
+	if outOfRange != nil {
+		fmt.Printf("Conductor %s: %s\n", formatArea(outOfRange.ParallelSize, units), describeStranding(outOfRange.ParallelSize, stranding, units))
+	} else {
+		fmt.Printf("Conductor %s: %s\n", formatArea(recommendedMetric, units), describeStranding(recommendedMetric, stranding, units))
+	}
+	fmt.Printf("Conductor AWG %s: %s\n", recommendedAWG, describeStranding(awgArea, stranding, units))
+	printDiagnostic(os.Stdout, awgRangeDiagnostic(profile.AWGSizes, requiredArea))
+	if outOfRange == nil {
+		// Same lookup as Circuit.outerDiameter, with the chosen wall series
+		if outerDiameter, fromTable := wireTypeOuterDiameter(wireTypeKey, wireType, recommendedMetric, wall); fromTable {
+			fmt.Printf("Outer Diameter (%s wall, max): %s\n", wall, formatDiameter(outerDiameter, units))
+		} else {
+			fmt.Printf("Outer Diameter (%s, estimated): %s\n", wireType.Name, formatDiameter(outerDiameter, units))
+		}
+	}
 	fmt.Println()
 
 	// Calculate actual voltage drop with recommended sizes
@@ -473,13 +819,50 @@ func main() {
 	resistivity := calculateResistivityAtTemp(material, effectiveTemp)
 	distanceFactor := map[bool]float64{true: 2.0, false: 1.0}[roundTrip]
 
-	// Metric size
-	actualDropMetric := (current * resistivity * length * distanceFactor) / closestMetric
-	actualDropPercentMetric := (actualDropMetric / voltage) * 100
-	fmt.Printf("With %.2f mm²: %.2f V (%.2f%%)\n", closestMetric, actualDropMetric, actualDropPercentMetric)
+	// Metric size (parallel conductors if out of range)
+	var dropDiagnostics []*Diagnostic
+	if outOfRange != nil {
+		parallelArea := float64(outOfRange.ParallelCount) * outOfRange.ParallelSize
+		actualDropParallel := (current*resistivity*length*distanceFactor)/parallelArea + componentDrop
+		actualDropPercentParallel := (actualDropParallel / voltage) * 100
+		label := fmt.Sprintf("%d × %s", outOfRange.ParallelCount, formatArea(outOfRange.ParallelSize, units))
+		fmt.Printf("With %s: %.2f V (%.2f%%)\n", label, actualDropParallel, actualDropPercentParallel)
+		dropDiagnostics = append(dropDiagnostics, checkVoltageDrop(label, actualDropPercentParallel, maxVoltageDropPercent))
+	} else {
+		actualDropMetric := (current*resistivity*length*distanceFactor)/recommendedMetric + componentDrop
+		actualDropPercentMetric := (actualDropMetric / voltage) * 100
+		fmt.Printf("With %s: %.2f V (%.2f%%)\n", formatArea(recommendedMetric, units), actualDropMetric, actualDropPercentMetric)
+		dropDiagnostics = append(dropDiagnostics, checkVoltageDrop(formatArea(recommendedMetric, units), actualDropPercentMetric, maxVoltageDropPercent))
+	}
 
 	// AWG size
-	actualDropAWG := (current * resistivity * length * distanceFactor) / awgArea
+	actualDropAWG := (current*resistivity*length*distanceFactor)/awgArea + componentDrop
 	actualDropPercentAWG := (actualDropAWG / voltage) * 100
-	fmt.Printf("With AWG %s (%.2f mm²): %.2f V (%.2f%%)\n", closestAWG, awgArea, actualDropAWG, actualDropPercentAWG)
+	fmt.Printf("With AWG %s (%s): %.2f V (%.2f%%)\n", recommendedAWG, formatArea(awgArea, units), actualDropAWG, actualDropPercentAWG)
+	dropDiagnostics = append(dropDiagnostics, checkVoltageDrop("AWG "+recommendedAWG, actualDropPercentAWG, maxVoltageDropPercent))
+
+	// Fixed conductor size
+	if fixedArea > 0 {
+		actualDropFixed := (current*resistivity*length*distanceFactor)/fixedArea + componentDrop
+		actualDropPercentFixed := (actualDropFixed / voltage) * 100
+		fmt.Printf("With fixed %s (%s): %.2f V (%.2f%%)\n", fixedLabel, formatArea(fixedArea, units), actualDropFixed, actualDropPercentFixed)
+	}
+	for _, d := range dropDiagnostics {
+		printDiagnostic(os.Stdout, d)
+	}
+	fmt.Println()
+
+	// Conductor weight and cost of the recommended sizes
+	fmt.Printf("=== Conductor Weight and Cost (%s, %.2f per kg) ===\n", material.Name, material.PricePerKg)
+	cableLength := length * distanceFactor
+	if outOfRange != nil {
+		parallelLength := cableLength * float64(outOfRange.ParallelCount)
+		estimate := estimateCable(outOfRange.ParallelSize, parallelLength, material)
+		fmt.Printf("%d × %s: %.1f g/m per conductor, %.3f kg total, cost %.2f\n", outOfRange.ParallelCount, formatArea(outOfRange.ParallelSize, units), estimate.MassPerMeter*1000, estimate.TotalMass, estimate.Cost)
+	} else {
+		estimate := estimateCable(recommendedMetric, cableLength, material)
+		fmt.Printf("%s: %.1f g/m, %.3f kg total, cost %.2f\n", formatArea(recommendedMetric, units), estimate.MassPerMeter*1000, estimate.TotalMass, estimate.Cost)
+	}
+	estimate := estimateCable(awgArea, cableLength, material)
+	fmt.Printf("AWG %s: %.1f g/m, %.3f kg total, cost %.2f\n", recommendedAWG, estimate.MassPerMeter*1000, estimate.TotalMass, estimate.Cost)
 }
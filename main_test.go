@@ -286,11 +286,19 @@ func TestFindClosestAWG(t *testing.T) {
 			tolerance:    0.0001,
 		},
 		{
-			name:         "very small area - AWG 18",
+			name:         "small area - AWG 20",
 			requiredArea: 0.5,
-			wantLabel:    "18",
-			wantArea:     0.823,
-			wantDiff:     0.323,
+			wantLabel:    "20",
+			wantArea:     0.5176,
+			wantDiff:     0.0176,
+			tolerance:    0.0001,
+		},
+		{
+			name:         "signal wiring - AWG 26",
+			requiredArea: 0.13,
+			wantLabel:    "26",
+			wantArea:     0.1288,
+			wantDiff:     0.0012,
 			tolerance:    0.0001,
 		},
 		{
@@ -305,8 +313,16 @@ func TestFindClosestAWG(t *testing.T) {
 			name:         "between AWG 1 and 1/0",
 			requiredArea: 48.0,
 			wantLabel:    "1/0",
-			wantArea:     53.49,
-			wantDiff:     5.49,
+			wantArea:     53.48,
+			wantDiff:     5.48,
+			tolerance:    0.0001,
+		},
+		{
+			name:         "large feeder - 500 kcmil",
+			requiredArea: 250.0,
+			wantLabel:    "500 kcmil",
+			wantArea:     253.4,
+			wantDiff:     3.4,
 			tolerance:    0.0001,
 		},
 	}
@@ -327,6 +343,91 @@ func TestFindClosestAWG(t *testing.T) {
 	}
 }
 
+func TestAWGSizes(t *testing.T) {
+	first := awgSizes[0]
+	last := awgSizes[len(awgSizes)-1]
+	if first.Label != "40" {
+		t.Errorf("smallest AWG size = %v, want 40", first.Label)
+	}
+	if last.Label != "1000 kcmil" {
+		t.Errorf("largest AWG size = %v, want 1000 kcmil", last.Label)
+	}
+
+	for i := 1; i < len(awgSizes); i++ {
+		if awgSizes[i].Area <= awgSizes[i-1].Area {
+			t.Errorf("AWG sizes not ascending: %s (%v) after %s (%v)", awgSizes[i].Label, awgSizes[i].Area, awgSizes[i-1].Label, awgSizes[i-1].Area)
+		}
+	}
+
+	tests := []struct {
+		gauge     int
+		wantLabel string
+		wantArea  float64
+	}{
+		{gauge: 30, wantLabel: "30", wantArea: 0.05093},
+		{gauge: 12, wantLabel: "12", wantArea: 3.309},
+		{gauge: 0, wantLabel: "1/0", wantArea: 53.48},
+		{gauge: -3, wantLabel: "4/0", wantArea: 107.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantLabel, func(t *testing.T) {
+			if got := awgLabel(tt.gauge); got != tt.wantLabel {
+				t.Errorf("awgLabel(%d) = %v, want %v", tt.gauge, got, tt.wantLabel)
+			}
+			if got := roundSignificant(awgGaugeArea(tt.gauge), 4); math.Abs(got-tt.wantArea) > 0.0001 {
+				t.Errorf("awgGaugeArea(%d) = %v, want %v", tt.gauge, got, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestAWGRangeDiagnostic(t *testing.T) {
+	tests := []struct {
+		name         string
+		sizes        []AWGSize
+		requiredArea float64
+		wantInRange  bool
+	}{
+		{
+			name:         "within range",
+			sizes:        awgSizes,
+			requiredArea: 10.0,
+			wantInRange:  true,
+		},
+		{
+			name:         "below 40 AWG",
+			sizes:        awgSizes,
+			requiredArea: 0.001,
+			wantInRange:  true, // 40 AWG still satisfies it
+		},
+		{
+			name:         "above 1000 kcmil",
+			sizes:        awgSizes,
+			requiredArea: 600.0,
+			wantInRange:  false,
+		},
+		{
+			name:         "above 4/0 for SAE J1128",
+			sizes:        saeJ1128AWGSizes,
+			requiredArea: 150.0,
+			wantInRange:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := awgRangeDiagnostic(tt.sizes, tt.requiredArea)
+			if inRange := d == nil; inRange != tt.wantInRange {
+				t.Errorf("awgRangeDiagnostic() = %v, want in range %v", d, tt.wantInRange)
+			}
+			if d != nil && (d.Code != CodeAWGOutOfRange || d.Message == "") {
+				t.Errorf("awgRangeDiagnostic() = %+v, want an %s diagnostic with a message", d, CodeAWGOutOfRange)
+			}
+		})
+	}
+}
+
 func TestMaterials(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// Monte Carlo tolerance analysis
+//
+// Samples resistivity, temperature, length and current from given
+// distributions and evaluates the actual voltage drop of the recommended
+// size for every sample.
+
+// DistributionKind selects the shape of a distribution.
+type DistributionKind string
+
+const (
+	DistributionNormal  DistributionKind = "normal"
+	DistributionUniform DistributionKind = "uniform"
+)
+
+// Distribution of an uncertain input.
+//
+// Spread is the standard deviation for normal distributions and the
+// half-width for uniform distributions.
+type Distribution struct {
+	Kind   DistributionKind
+	Mean   float64
+	Spread float64
+}
+
+// Draw a sample from the distribution.
+func (d Distribution) sample(rng *rand.Rand) float64 {
+	if d.Kind == DistributionUniform {
+		return d.Mean + d.Spread*(2*rng.Float64()-1)
+	}
+	return d.Mean + d.Spread*rng.NormFloat64()
+}
+
+// MonteCarloInputs holds the distributions of the sampled inputs.
+type MonteCarloInputs struct {
+	ResistivityFactor Distribution // Multiplier on ρ(T), mean 1
+	Temperature       Distribution // Effective operating temperature (°C)
+	Length            Distribution // m
+	Current           Distribution // A
+}
+
+// MonteCarloResult summarizes the sampled voltage drops (in %).
+type MonteCarloResult struct {
+	Samples           int
+	Mean              float64
+	Percentiles       []float64 // Drop (%) at monteCarloPercentiles
+	ExceedProbability float64   // Share of samples above the maximum drop
+}
+
+// Percentiles reported by the Monte Carlo analysis
+var monteCarloPercentiles = []float64{5, 50, 95, 99}
+
+// Build the input distributions for a circuit.
+//
+// Tolerances are relative (in %) for resistivity, length and current and
+// absolute (°C) for the temperature, interpreted as standard deviation
+// (normal) or half-width (uniform).
+func monteCarloInputsFor(c Circuit, kind DistributionKind, resistivityTol, tempSpread, lengthTol, currentTol float64) MonteCarloInputs {
+	return MonteCarloInputs{
+		ResistivityFactor: Distribution{Kind: kind, Mean: 1, Spread: resistivityTol / 100},
+		Temperature:       Distribution{Kind: kind, Mean: c.effectiveTemp(), Spread: tempSpread},
+		Length:            Distribution{Kind: kind, Mean: c.Length, Spread: c.Length * lengthTol / 100},
+		Current:           Distribution{Kind: kind, Mean: c.Current, Spread: c.Current * currentTol / 100},
+	}
+}
+
+// Run a Monte Carlo analysis of the voltage drop with a given conductor area.
+//
+// For each sample:
+//
+//	drop% = (I × ρ(T) × f_ρ × L × distanceFactor / A + I × R_components) / V × 100
+//
+// Negative length and current samples are clamped to zero.
+func monteCarloDrop(c Circuit, material CableMaterial, area float64, in MonteCarloInputs, samples int, rng *rand.Rand) MonteCarloResult {
+	distanceFactor := 1.0
+	if c.RoundTrip {
+		distanceFactor = 2.0
+	}
+
+	componentResistance, _ := componentsResistance(c.Components)
+	maxDrop, _ := c.maxDropPercent()
+
+	drops := make([]float64, samples)
+	var sum float64
+	exceeded := 0
+	for i := range drops {
+		resistivity := calculateResistivityAtTemp(material, in.Temperature.sample(rng)) * in.ResistivityFactor.sample(rng)
+		length := math.Max(0, in.Length.sample(rng))
+		current := math.Max(0, in.Current.sample(rng))
+
+		drop := (current*resistivity*length*distanceFactor/area + current*componentResistance) / c.Voltage * 100
+		drops[i] = drop
+		sum += drop
+		if drop > maxDrop {
+			exceeded++
+		}
+	}
+
+	sort.Float64s(drops)
+	result := MonteCarloResult{
+		Samples:           samples,
+		Mean:              sum / float64(samples),
+		ExceedProbability: float64(exceeded) / float64(samples),
+	}
+	for _, p := range monteCarloPercentiles {
+		result.Percentiles = append(result.Percentiles, percentile(drops, p))
+	}
+	return result
+}
+
+// Percentile of sorted values with linear interpolation.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// Run the montecarlo command.
+func runMonteCarloCommand(args []string) int {
+	fs := flag.NewFlagSet("montecarlo", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "montecarlo"
+	bindCircuitFlags(fs, &c)
+	samples := fs.Int("samples", 10000, "number of samples")
+	seed := fs.Uint64("seed", 1, "random seed")
+	dist := fs.String("dist", string(DistributionNormal), "distribution (normal/uniform)")
+	resistivityTol := fs.Float64("resistivity-tol", 2, "resistivity tolerance (%)")
+	tempSpread := fs.Float64("temp-spread", 5, "operating temperature spread (°C)")
+	lengthTol := fs.Float64("length-tol", 5, "length tolerance (%)")
+	currentTol := fs.Float64("current-tol", 10, "current tolerance (%)")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	kind := DistributionKind(*dist)
+	if kind != DistributionNormal && kind != DistributionUniform {
+		fmt.Printf("Error: Unknown distribution %q (normal/uniform).\n", *dist)
+		return exitUsage
+	}
+	if *samples <= 0 {
+		fmt.Println("Error: Number of samples must be positive.")
+		return exitUsage
+	}
+	if err := c.recalculate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	material, _, _, _ := c.resolve()
+
+	inputs := monteCarloInputsFor(c, kind, *resistivityTol, *tempSpread, *lengthTol, *currentTol)
+	rng := rand.New(rand.NewPCG(*seed, *seed))
+	result := monteCarloDrop(c, material, c.chosenArea(), inputs, *samples, rng)
+
+	fmt.Println("=== Monte Carlo Voltage Drop Analysis ===")
+	fmt.Printf("Recommended size: %s (required %.2f mm²)\n", chosenSizeLabel(c), c.RequiredArea)
+	fmt.Printf("Samples: %d (%s), seed %d\n", result.Samples, kind, *seed)
+	fmt.Printf("Nominal drop: %.2f%%, mean %.2f%%\n", c.voltageDrop(material, c.chosenArea())/c.Voltage*100, result.Mean)
+	fmt.Println()
+	for i, p := range monteCarloPercentiles {
+		fmt.Printf("P%-3.0f %.2f%% (%.3f V)\n", p, result.Percentiles[i], result.Percentiles[i]*c.Voltage/100)
+	}
+	fmt.Println()
+	maxDrop, _ := c.maxDropPercent()
+	fmt.Printf("Probability of exceeding %.2f%%: %.1f%%\n", maxDrop, result.ExceedProbability*100)
+	return exitOK
+}
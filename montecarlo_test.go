@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMonteCarloDrop(t *testing.T) {
+	c := testCircuit("montecarlo")
+	c.Current = 20
+	if err := c.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	material, _, _, _ := c.resolve()
+	area := c.chosenArea()
+	nominal := c.voltageDrop(material, area) / c.Voltage * 100
+
+	t.Run("zero spread", func(t *testing.T) {
+		in := monteCarloInputsFor(c, DistributionNormal, 0, 0, 0, 0)
+		result := monteCarloDrop(c, material, area, in, 100, rand.New(rand.NewPCG(1, 1)))
+		for i, p := range result.Percentiles {
+			if math.Abs(p-nominal) > 1e-9 {
+				t.Errorf("P%v = %v, want %v", monteCarloPercentiles[i], p, nominal)
+			}
+		}
+		if result.ExceedProbability != 0 {
+			t.Errorf("ExceedProbability = %v, want 0", result.ExceedProbability)
+		}
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		in := monteCarloInputsFor(c, DistributionNormal, 2, 5, 5, 10)
+		result := monteCarloDrop(c, material, area, in, 10000, rand.New(rand.NewPCG(1, 1)))
+		if math.Abs(result.Percentiles[1]-nominal) > 0.05 {
+			t.Errorf("P50 = %.3f, want about %.3f", result.Percentiles[1], nominal)
+		}
+		for i := 1; i < len(result.Percentiles); i++ {
+			if result.Percentiles[i] < result.Percentiles[i-1] {
+				t.Errorf("percentiles not ascending: %v", result.Percentiles)
+			}
+		}
+		// Nominal 2.92% against a 3% limit: a sizeable share of samples exceeds it
+		if result.ExceedProbability < 0.2 || result.ExceedProbability > 0.6 {
+			t.Errorf("ExceedProbability = %v, want between 0.2 and 0.6", result.ExceedProbability)
+		}
+	})
+
+	t.Run("uniform bounds", func(t *testing.T) {
+		in := monteCarloInputsFor(c, DistributionUniform, 0, 0, 0, 10)
+		result := monteCarloDrop(c, material, area, in, 1000, rand.New(rand.NewPCG(1, 1)))
+		if result.Percentiles[0] < nominal*0.9 || result.Percentiles[3] > nominal*1.1 {
+			t.Errorf("percentiles %v outside ±10%% of %.3f", result.Percentiles, nominal)
+		}
+	})
+
+	t.Run("same seed", func(t *testing.T) {
+		in := monteCarloInputsFor(c, DistributionNormal, 2, 5, 5, 10)
+		a := monteCarloDrop(c, material, area, in, 500, rand.New(rand.NewPCG(7, 7)))
+		b := monteCarloDrop(c, material, area, in, 500, rand.New(rand.NewPCG(7, 7)))
+		if a.Mean != b.Mean {
+			t.Errorf("means differ with the same seed: %v vs %v", a.Mean, b.Mean)
+		}
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{50, 3},
+		{100, 5},
+		{95, 4.8},
+	}
+	for _, tt := range tests {
+		if got := percentile(values, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import "math"
+
+// Standard DC system voltages proposed when a conductor is out of range
+var standardSystemVoltages = []float64{12.0, 24.0, 48.0}
+
+// OutOfRangeResult describes a required area that no single standard
+// conductor of a size series satisfies, together with alternatives.
+type OutOfRangeResult struct {
+	RequiredArea float64
+	LargestSize  float64
+
+	// Parallel conductors: ParallelCount conductors of ParallelSize each
+	ParallelCount int
+	ParallelSize  float64
+
+	// Lowest standard system voltage at which a single standard conductor
+	// suffices for the same load power, and the area required there.
+	// SuggestedVoltage is 0 if no higher standard voltage helps.
+	SuggestedVoltage float64
+	SuggestedArea    float64
+}
+
+// Check whether the required area exceeds all sizes of a series.
+//
+// Returns nil if a single standard conductor satisfies the requirement.
+// Otherwise the result proposes:
+//   - parallel conductors: n = ⌈A_required / A_largest⌉ conductors of the
+//     smallest standard size s with n × s ≥ A_required
+//   - a higher system voltage: for the same load power, the current scales
+//     with 1/V and the allowed drop with V, so A' = A × (V / V')²
+func checkOutOfRange(sizes []float64, requiredArea, voltage float64) *OutOfRangeResult {
+	largest := sizes[len(sizes)-1]
+	if requiredArea <= largest {
+		return nil
+	}
+
+	result := &OutOfRangeResult{
+		RequiredArea:  requiredArea,
+		LargestSize:   largest,
+		ParallelCount: int(math.Ceil(requiredArea / largest)),
+	}
+
+	for _, size := range sizes {
+		if float64(result.ParallelCount)*size >= requiredArea {
+			result.ParallelSize = size
+			break
+		}
+	}
+
+	for _, systemVoltage := range standardSystemVoltages {
+		if systemVoltage <= voltage {
+			continue
+		}
+		area := requiredArea * math.Pow(voltage/systemVoltage, 2)
+		if area <= largest {
+			result.SuggestedVoltage = systemVoltage
+			result.SuggestedArea = area
+			break
+		}
+	}
+
+	return result
+}
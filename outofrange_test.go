@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckOutOfRange(t *testing.T) {
+	tests := []struct {
+		name              string
+		requiredArea      float64
+		voltage           float64
+		wantOutOfRange    bool
+		wantParallelCount int
+		wantParallelSize  float64
+		wantVoltage       float64
+		wantSuggestedArea float64
+	}{
+		{
+			name:           "within largest size",
+			requiredArea:   200.0,
+			voltage:        12.0,
+			wantOutOfRange: false,
+		},
+		{
+			name:              "400 mm² at 12V",
+			requiredArea:      400.0,
+			voltage:           12.0,
+			wantOutOfRange:    true,
+			wantParallelCount: 2,
+			wantParallelSize:  240.0, // 2 × 185 = 370 < 400
+			wantVoltage:       24.0,
+			wantSuggestedArea: 100.0, // 400 × (12/24)²
+		},
+		{
+			name:              "1000 mm² at 12V needs 48V",
+			requiredArea:      1000.0,
+			voltage:           12.0,
+			wantOutOfRange:    true,
+			wantParallelCount: 5,
+			wantParallelSize:  240.0,
+			wantVoltage:       48.0, // 1000 × (12/24)² = 250 > 240, so 48V
+			wantSuggestedArea: 62.5,
+		},
+		{
+			name:              "300 mm² at 48V has no higher standard voltage",
+			requiredArea:      300.0,
+			voltage:           48.0,
+			wantOutOfRange:    true,
+			wantParallelCount: 2,
+			wantParallelSize:  150.0,
+			wantVoltage:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkOutOfRange(standardMetricSizes, tt.requiredArea, tt.voltage)
+			if (got != nil) != tt.wantOutOfRange {
+				t.Fatalf("checkOutOfRange() = %v, want out of range %v", got, tt.wantOutOfRange)
+			}
+			if got == nil {
+				return
+			}
+			if got.ParallelCount != tt.wantParallelCount {
+				t.Errorf("ParallelCount = %v, want %v", got.ParallelCount, tt.wantParallelCount)
+			}
+			if got.ParallelSize != tt.wantParallelSize {
+				t.Errorf("ParallelSize = %v, want %v", got.ParallelSize, tt.wantParallelSize)
+			}
+			if got.SuggestedVoltage != tt.wantVoltage {
+				t.Errorf("SuggestedVoltage = %v, want %v", got.SuggestedVoltage, tt.wantVoltage)
+			}
+			if tt.wantVoltage > 0 && math.Abs(got.SuggestedArea-tt.wantSuggestedArea) > 0.0001 {
+				t.Errorf("SuggestedArea = %v, want %v", got.SuggestedArea, tt.wantSuggestedArea)
+			}
+			if float64(got.ParallelCount)*got.ParallelSize < tt.requiredArea {
+				t.Errorf("parallel conductors %d × %v do not cover %v", got.ParallelCount, got.ParallelSize, tt.requiredArea)
+			}
+		})
+	}
+}
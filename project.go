@@ -0,0 +1,731 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Project files
+//
+// A project is a JSON file holding a set of named circuits with all inputs
+// of calculateCableArea plus the sizes chosen by the last recalculation.
+// Projects are managed with "cablecalc project <command> <file>".
+
+// Circuit is a single named cable run of a project.
+type Circuit struct {
+	Name                  string             `json:"name"`
+	Voltage               float64            `json:"voltage"`
+	Current               float64            `json:"current"`
+	Length                float64            `json:"length"`
+	MaxVoltageDropPercent float64            `json:"max_voltage_drop_percent"`
+	Material              string             `json:"material"`
+	RoundTrip             bool               `json:"round_trip"`
+	AmbientTempCelsius    float64            `json:"ambient_temp_celsius"`
+	Installation          InstallationMethod `json:"installation"`
+	WireType              string             `json:"wire_type"`
+	Profile               string             `json:"profile"`
+
+	// Minimum load voltage mode: the maximum voltage drop is derived from
+	// MinLoadVoltage and the source internal resistance when set
+	MinLoadVoltage   float64 `json:"min_load_voltage,omitempty"`
+	SourceResistance float64 `json:"source_resistance_ohm,omitempty"`
+
+	// Series components (componentLibrary keys, optionally "key:n")
+	Components []string `json:"components,omitempty"`
+
+	// IEC 60228 stranding class, overriding the wire type's default (0 = default)
+	Stranding int `json:"stranding,omitempty"`
+
+	// Environment corrections of the effective temperature
+	AltitudeMeters  float64 `json:"altitude_m,omitempty"`
+	SolarIrradiance float64 `json:"solar_irradiance_w_m2,omitempty"` // Direct sunlight (0 = shaded)
+
+	// Soil conditions of buried installations (0 = reference conditions)
+	SoilResistivity   float64  `json:"soil_resistivity_km_w,omitempty"`
+	BurialDepth       float64  `json:"burial_depth_m,omitempty"`
+	GroundTempCelsius *float64 `json:"ground_temp_celsius,omitempty"` // nil = ambient temperature
+
+	// Results of the last recalculation
+	RequiredArea float64 `json:"required_area,omitempty"`
+	MetricSize   float64 `json:"metric_size,omitempty"`
+	Conductors   int     `json:"conductors,omitempty"` // Parallel conductors of MetricSize (0 or 1 = single)
+	AWGSize      string  `json:"awg_size,omitempty"`
+}
+
+// Project is a named set of circuits, optionally routed through harness sections.
+type Project struct {
+	Name     string           `json:"name"`
+	Circuits []Circuit        `json:"circuits"`
+	Sections []HarnessSection `json:"sections,omitempty"`
+}
+
+// Default inputs for new circuits, matching the interactive defaults.
+func defaultCircuit() Circuit {
+	return Circuit{
+		MaxVoltageDropPercent: 3.0,
+		Material:              "copper",
+		AmbientTempCelsius:    20.0,
+		Installation:          InstallationInAir,
+		WireType:              "generic",
+		Profile:               "standard",
+	}
+}
+
+// Look up the material, wire type and cable profile of a circuit.
+//
+// Wire types are looked up in wireTypes first, then as ISO 6722
+// temperature class (a-e).
+func (c Circuit) resolve() (CableMaterial, WireType, CableProfile, error) {
+	material, ok := materials[c.Material]
+	if !ok {
+		return CableMaterial{}, WireType{}, CableProfile{}, fmt.Errorf("circuit %q: unknown material %q", c.Name, c.Material)
+	}
+	wireType, ok := wireTypes[c.WireType]
+	if !ok {
+		wireType, ok = iso6722TempClasses[c.WireType]
+	}
+	if !ok {
+		return CableMaterial{}, WireType{}, CableProfile{}, fmt.Errorf("circuit %q: unknown wire type %q", c.Name, c.WireType)
+	}
+	profile, ok := cableProfiles[c.Profile]
+	if !ok {
+		return CableMaterial{}, WireType{}, CableProfile{}, fmt.Errorf("circuit %q: unknown cable profile %q", c.Name, c.Profile)
+	}
+	return material, wireType, profile, nil
+}
+
+// Check the inputs of a circuit.
+func (c Circuit) validate() error {
+	switch {
+	case c.Name == "":
+		return errors.New("circuit name is required")
+	case c.Voltage <= 0 || c.Voltage > 50:
+		return fmt.Errorf("circuit %q: voltage must be between 0 and 50V (inclusive)", c.Name)
+	case c.Current <= 0:
+		return fmt.Errorf("circuit %q: current must be positive", c.Name)
+	case c.Length <= 0:
+		return fmt.Errorf("circuit %q: length must be positive", c.Name)
+	case c.MinLoadVoltage < 0 || c.MinLoadVoltage >= c.Voltage:
+		return fmt.Errorf("circuit %q: minimum load voltage must be below the system voltage", c.Name)
+	case c.SourceResistance < 0:
+		return fmt.Errorf("circuit %q: source resistance must not be negative", c.Name)
+	case c.AltitudeMeters < 0:
+		return fmt.Errorf("circuit %q: altitude must not be negative", c.Name)
+	case c.SolarIrradiance < 0 || c.SolarIrradiance > maxSolarIrradiance:
+		return fmt.Errorf("circuit %q: solar irradiance must be between 0 and %.0f W/m²", c.Name, maxSolarIrradiance)
+	case c.MinLoadVoltage == 0 && (c.MaxVoltageDropPercent <= 0 || c.MaxVoltageDropPercent > 10):
+		return fmt.Errorf("circuit %q: maximum voltage drop must be between 0 and 10%%", c.Name)
+	}
+	if _, ok := installationTempAdjustments[c.Installation]; !ok {
+		return fmt.Errorf("circuit %q: unknown installation method %q", c.Name, c.Installation)
+	}
+	if _, err := componentsResistance(c.Components); err != nil {
+		return fmt.Errorf("circuit %q: %w", c.Name, err)
+	}
+	if c.Stranding != 0 {
+		if _, err := lookupStrandingClass(c.Stranding); err != nil {
+			return fmt.Errorf("circuit %q: %w", c.Name, err)
+		}
+	}
+	if _, _, _, err := c.resolve(); err != nil {
+		return err
+	}
+	if _, err := soilCorrectionFactor(c.Installation, c.SoilResistivity, c.BurialDepth); err != nil {
+		return fmt.Errorf("circuit %q: %w", c.Name, err)
+	}
+	if err := checkGroundTemp(c.Installation, c.baseTemp(), c.wireTypeWithStranding().MaxTempCelsius); err != nil {
+		return fmt.Errorf("circuit %q: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Wire type of a circuit with its stranding class override applied.
+func (c Circuit) wireTypeWithStranding() WireType {
+	_, wireType, _, _ := c.resolve()
+	if c.Stranding != 0 {
+		wireType.Stranding = c.Stranding
+	}
+	return wireType
+}
+
+// Stranding class of a circuit's conductors.
+func (c Circuit) strandingClass() StrandingClass {
+	return strandingClasses[c.wireTypeWithStranding().Stranding]
+}
+
+// Temperature around a circuit: the ground temperature of buried
+// installations, if given, otherwise the ambient temperature.
+func (c Circuit) baseTemp() float64 {
+	if isBuried(c.Installation) && c.GroundTempCelsius != nil {
+		return *c.GroundTempCelsius
+	}
+	return c.AmbientTempCelsius
+}
+
+// Current rating factor of a circuit from soil resistivity and burial
+// depth (1 above ground). The ground temperature is the base temperature
+// instead.
+func (c Circuit) soilFactor() float64 {
+	k, _ := soilCorrectionFactor(c.Installation, c.SoilResistivity, c.BurialDepth)
+	return k
+}
+
+// Temperature rise of a circuit on top of calculateEffectiveTemp from
+// altitude, solar radiation and soil conditions.
+func (c Circuit) correctionTempRise() float64 {
+	return environmentTempRise(c.Installation, c.AltitudeMeters, c.SolarIrradiance) + soilTempRise(c.Installation, c.soilFactor())
+}
+
+// Effective operating temperature of a circuit, including altitude, solar
+// radiation and soil corrections.
+func (c Circuit) effectiveTemp() float64 {
+	return calculateCorrectedEffectiveTemp(c.baseTemp(), c.Installation, c.AltitudeMeters, c.SolarIrradiance) + soilTempRise(c.Installation, c.soilFactor())
+}
+
+// Ambient temperature of a circuit raised by its altitude, solar radiation
+// and soil corrections, for calculateCableArea.
+func (c Circuit) correctedAmbient() float64 {
+	return c.baseTemp() + c.correctionTempRise()
+}
+
+// Total conductor area chosen for a circuit (mm²), including parallel conductors.
+func (c Circuit) chosenArea() float64 {
+	if c.Conductors > 1 {
+		return c.MetricSize * float64(c.Conductors)
+	}
+	return c.MetricSize
+}
+
+// Actual voltage drop (V) of a circuit with the given conductor area,
+// including its series components.
+func (c Circuit) voltageDrop(material CableMaterial, area float64) float64 {
+	distanceFactor := 1.0
+	if c.RoundTrip {
+		distanceFactor = 2.0
+	}
+	resistivity := calculateResistivityAtTemp(material, c.effectiveTemp())
+	componentResistance, _ := componentsResistance(c.Components)
+	return (c.Current*resistivity*c.Length*distanceFactor)/area + c.Current*componentResistance
+}
+
+// Voltage drop budget of a circuit left for the conductor (%), after the
+// drop of its series components.
+func (c Circuit) conductorDropPercent() (float64, error) {
+	componentResistance, err := componentsResistance(c.Components)
+	if err != nil {
+		return 0, err
+	}
+	maxDrop, err := c.maxDropPercent()
+	if err != nil {
+		return 0, err
+	}
+	drop, err := conductorDropBudget(c.Voltage, c.Current, maxDrop, componentResistance)
+	if err != nil {
+		return 0, fmt.Errorf("circuit %q: %w", c.Name, err)
+	}
+	return drop, nil
+}
+
+// Maximum voltage drop of a circuit (%).
+//
+// Derived from the minimum load voltage if set, else MaxVoltageDropPercent.
+// The derived value is not stored, so the circuit keeps its own -drop.
+func (c Circuit) maxDropPercent() (float64, error) {
+	if c.MinLoadVoltage == 0 {
+		return c.MaxVoltageDropPercent, nil
+	}
+	drop, err := allowedDropForMinVoltage(c.Voltage, c.Current, c.SourceResistance, c.MinLoadVoltage)
+	if err != nil {
+		return 0, fmt.Errorf("circuit %q: %w", c.Name, err)
+	}
+	return drop, nil
+}
+
+// Recalculate the required area and chosen sizes of a circuit.
+func (c *Circuit) recalculate() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	dropPercent, err := c.conductorDropPercent()
+	if err != nil {
+		return err
+	}
+	material, _, profile, _ := c.resolve()
+
+	c.RequiredArea = calculateCableArea(c.Voltage, c.Current, c.Length, dropPercent, material, c.RoundTrip, c.correctedAmbient(), c.Installation)
+	c.MetricSize = findSmallestSizeIn(profile.MetricSizes, c.RequiredArea)
+	c.Conductors = 0
+	if outOfRange := checkOutOfRange(profile.MetricSizes, c.RequiredArea, c.Voltage); outOfRange != nil {
+		c.MetricSize = outOfRange.ParallelSize
+		c.Conductors = outOfRange.ParallelCount
+	}
+	c.AWGSize, _ = findSmallestAWGIn(profile.AWGSizes, c.RequiredArea)
+	return nil
+}
+
+// Find a circuit by name.
+func (p *Project) findCircuit(name string) *Circuit {
+	for i := range p.Circuits {
+		if p.Circuits[i].Name == name {
+			return &p.Circuits[i]
+		}
+	}
+	return nil
+}
+
+// Recalculate all circuits of a project, including the derating of their
+// harness sections.
+func (p *Project) recalculate() error {
+	for i := range p.Circuits {
+		if err := p.Circuits[i].recalculate(); err != nil {
+			return err
+		}
+	}
+	return p.applyHarnessDerating()
+}
+
+// Load a project from a JSON file.
+func loadProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Project
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Save a project to a JSON file.
+func saveProject(path string, p *Project) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Register flags for all circuit inputs on a flag set.
+//
+// The current values of the circuit are used as defaults, so parsing only
+// changes the inputs given on the command line.
+func bindCircuitFlags(fs *flag.FlagSet, c *Circuit) {
+	fs.Float64Var(&c.Voltage, "voltage", c.Voltage, "system voltage (V)")
+	fs.Float64Var(&c.Current, "current", c.Current, "current (A)")
+	fs.Func("length", "cable length (m, or add 'ft' for feet)", func(s string) error {
+		length, err := parseLength(s, LengthMeters)
+		c.Length = length
+		return err
+	})
+	fs.Float64Var(&c.MaxVoltageDropPercent, "drop", c.MaxVoltageDropPercent, "maximum voltage drop (%)")
+	fs.Float64Var(&c.MinLoadVoltage, "min-load-voltage", c.MinLoadVoltage, "minimum load voltage (V), replaces -drop (0 = off)")
+	fs.Float64Var(&c.SourceResistance, "source-resistance", c.SourceResistance, "source internal resistance (Ω)")
+	fs.Func("components", "series components, e.g. crimp:4,blade-fuse (empty = none)", func(s string) error {
+		list, err := parseComponents(s)
+		c.Components = list
+		return err
+	})
+	fs.StringVar(&c.Material, "material", c.Material, "cable material")
+	fs.BoolVar(&c.RoundTrip, "round-trip", c.RoundTrip, "length is round trip")
+	fs.Float64Var(&c.AmbientTempCelsius, "ambient", c.AmbientTempCelsius, "ambient temperature (°C)")
+	fs.Func("ground-temp", "ground temperature of buried installations (°C, default: ambient)", func(s string) error {
+		groundTemp, err := strconv.ParseFloat(s, 64)
+		c.GroundTempCelsius = &groundTemp
+		return err
+	})
+	fs.Func("installation", "installation method (air/conduit/isolated/buried/duct)", func(s string) error {
+		c.Installation = InstallationMethod(strings.ToLower(s))
+		return nil
+	})
+	fs.Float64Var(&c.AltitudeMeters, "altitude", c.AltitudeMeters, "altitude above sea level (m)")
+	fs.Float64Var(&c.SolarIrradiance, "solar", c.SolarIrradiance, "direct solar irradiance (W/m², 0 = shaded)")
+	fs.Float64Var(&c.SoilResistivity, "soil-resistivity", c.SoilResistivity, "soil thermal resistivity of buried installations (K·m/W, 0 = 2.5)")
+	fs.Float64Var(&c.BurialDepth, "depth", c.BurialDepth, "burial depth of buried installations (m, 0 = 0.7)")
+	fs.StringVar(&c.WireType, "wire", c.WireType, "wire type")
+	fs.StringVar(&c.Profile, "profile", c.Profile, "cable profile (standard/automotive)")
+	fs.IntVar(&c.Stranding, "stranding", c.Stranding, "IEC 60228 stranding class 1/2/5/6 (0 = wire type default)")
+}
+
+// Print a project report with the chosen sizes of all circuits, followed by
+// their diagnostics except the suppressed codes.
+//
+// Returns the printed diagnostics.
+func printProjectReport(p *Project, suppressed map[string]bool) ([]Diagnostic, error) {
+	fmt.Printf("=== Project: %s ===\n", p.Name)
+	if len(p.Circuits) == 0 {
+		fmt.Println("No circuits.")
+		return nil, nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Circuit\tVoltage\tCurrent\tLength\tAmbient\tRequired\tMetric\tAWG\tDrop\tTemperature")
+	for _, c := range p.Circuits {
+		material, wireType, _, err := c.resolve()
+		if err != nil || c.MetricSize == 0 {
+			fmt.Fprintf(w, "%s\t%.1f V\t%.2f A\t%.2f m\t%.1f°C\t-\t-\t-\t-\tnot calculated\n", c.Name, c.Voltage, c.Current, c.Length, c.AmbientTempCelsius)
+			continue
+		}
+
+		metric := fmt.Sprintf("%.2f mm²", c.MetricSize)
+		if c.Conductors > 1 {
+			metric = fmt.Sprintf("%d × %.2f mm²", c.Conductors, c.MetricSize)
+		}
+		dropPercent := c.voltageDrop(material, c.chosenArea()) / c.Voltage * 100
+
+		temperature := "OK"
+		if isValid, warningMsg := ValidateWireTemperature(c.effectiveTemp(), wireType); !isValid {
+			temperature = "EXCEEDED"
+		} else if warningMsg != "" {
+			temperature = "CAUTION"
+		}
+
+		fmt.Fprintf(w, "%s\t%.1f V\t%.2f A\t%.2f m\t%.1f°C\t%.2f mm²\t%s\t%s\t%.2f%%\t%s\n",
+			c.Name, c.Voltage, c.Current, c.Length, c.AmbientTempCelsius, c.RequiredArea, metric, c.AWGSize, dropPercent, temperature)
+	}
+	w.Flush()
+
+	list, err := p.diagnostics()
+	if err != nil {
+		return nil, err
+	}
+	if shown := filterDiagnostics(list, suppressed); len(shown) > 0 {
+		fmt.Println()
+		printDiagnostics(os.Stdout, shown)
+	}
+	return list, nil
+}
+
+func printProjectUsage() {
+	fmt.Println("Usage: cablecalc project <command> <file> [arguments]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  create <file> [-name NAME]         Create an empty project")
+	fmt.Println("  add    <file> <circuit> [inputs] [-suppress codes] [-strict]")
+	fmt.Println("                                     Add a circuit and calculate it")
+	fmt.Println("  edit   <file> <circuit> [inputs] [-suppress codes] [-strict]")
+	fmt.Println("                                     Change inputs of a circuit and recalculate it")
+	fmt.Println("  recalc <file> [-ambient T] [-suppress codes] [-strict]")
+	fmt.Println("                                     Recalculate all circuits, optionally at a new ambient temperature")
+	fmt.Println("  report <file> [-suppress codes] [-strict]")
+	fmt.Println("                                     Show the chosen sizes and diagnostics of all circuits")
+	fmt.Println("  check  <file> [-json] [-suppress codes] [-strict]")
+	fmt.Println("                                     List the diagnostics of all circuits")
+	fmt.Println("  bom    <file> [-format csv|md] [-waste %] [-awg] [-weight] [-o out]")
+	fmt.Println("                                     Export a bill of materials")
+	fmt.Println("  section <file> <section> -circuits a,b,c")
+	fmt.Println("                                     Add or replace a harness section")
+	fmt.Println("  harness <file>                     Show bundle diameters and derated temperatures")
+	fmt.Println()
+	fmt.Println("-suppress and -strict are accepted by add, edit, recalc, report and check,")
+	fmt.Println("whose diagnostics all include the bundled harness temperatures; harness has")
+	fmt.Println("neither. Suppressed diagnostics are hidden but still fail -strict.")
+	fmt.Println()
+	fmt.Println("Circuit inputs:")
+	fs := flag.NewFlagSet("circuit", flag.ContinueOnError)
+	c := defaultCircuit()
+	bindCircuitFlags(fs, &c)
+	fs.SetOutput(os.Stdout)
+	fs.PrintDefaults()
+}
+
+// Run a project subcommand.
+func runProjectCommand(args []string) int {
+	if len(args) < 2 {
+		printProjectUsage()
+		return exitUsage
+	}
+	command, path, flagArgs := args[0], args[1], args[2:]
+
+	fs := flag.NewFlagSet("project "+command, flag.ContinueOnError)
+
+	switch command {
+	case "create":
+		name := fs.String("name", "", "project name")
+		if err := fs.Parse(flagArgs); err != nil {
+			return exitUsage
+		}
+		if _, err := os.Stat(path); err == nil {
+			fmt.Printf("Error: %s already exists.\n", path)
+			return exitError
+		}
+		if err := saveProject(path, &Project{Name: *name, Circuits: []Circuit{}}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		fmt.Printf("Created project %q in %s\n", *name, path)
+		return exitOK
+
+	case "add", "edit":
+		if len(flagArgs) < 1 {
+			printProjectUsage()
+			return exitUsage
+		}
+		name := flagArgs[0]
+
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+
+		existing := p.findCircuit(name)
+		c := defaultCircuit()
+		c.Name = name
+		switch {
+		case command == "add" && existing != nil:
+			fmt.Printf("Error: circuit %q already exists.\n", name)
+			return exitError
+		case command == "edit" && existing == nil:
+			fmt.Printf("Error: circuit %q not found.\n", name)
+			return exitError
+		case command == "edit":
+			c = *existing
+		}
+
+		bindCircuitFlags(fs, &c)
+		diagOpts := bindDiagnosticFlags(fs)
+		if err := fs.Parse(flagArgs[1:]); err != nil {
+			return exitUsage
+		}
+		if err := c.recalculate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if existing != nil {
+			*existing = c
+		} else {
+			p.Circuits = append(p.Circuits, c)
+		}
+		if err := p.applyHarnessDerating(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if err := saveProject(path, p); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		list, err := printProjectReport(p, diagOpts.Suppressed)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		return diagOpts.reportExitCode(list)
+
+	case "recalc":
+		ambient := fs.Float64("ambient", 0, "set the ambient temperature (°C) of all circuits")
+		diagOpts := bindDiagnosticFlags(fs)
+		if err := fs.Parse(flagArgs); err != nil {
+			return exitUsage
+		}
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "ambient" {
+				for i := range p.Circuits {
+					p.Circuits[i].AmbientTempCelsius = *ambient
+				}
+			}
+		})
+		if err := p.recalculate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if err := saveProject(path, p); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		list, err := printProjectReport(p, diagOpts.Suppressed)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		return diagOpts.reportExitCode(list)
+
+	case "report":
+		diagOpts := bindDiagnosticFlags(fs)
+		if err := fs.Parse(flagArgs); err != nil {
+			return exitUsage
+		}
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		list, err := printProjectReport(p, diagOpts.Suppressed)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		return diagOpts.reportExitCode(list)
+
+	case "check":
+		asJSON := fs.Bool("json", false, "write the diagnostics as JSON")
+		diagOpts := bindDiagnosticFlags(fs)
+		if err := fs.Parse(flagArgs); err != nil {
+			return exitUsage
+		}
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		list, err := p.diagnostics()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		shown := filterDiagnostics(list, diagOpts.Suppressed)
+
+		if *asJSON {
+			if err := writeDiagnosticsJSON(os.Stdout, shown); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return exitError
+			}
+			return diagOpts.exitCode(list)
+		}
+		switch {
+		case len(list) == 0:
+			fmt.Println("No diagnostics.")
+		case len(shown) == 0:
+			fmt.Printf("No diagnostics (%d suppressed).\n", len(list))
+		default:
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "Severity\tCode\tCircuit\tField\tMessage")
+			for _, d := range shown {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Severity, d.Code, d.Circuit, d.Field, d.Message)
+			}
+			w.Flush()
+		}
+		return diagOpts.reportExitCode(list)
+
+	case "bom":
+		format := fs.String("format", "md", "output format (csv/md)")
+		waste := fs.Float64("waste", 10, "cut-waste allowance (%)")
+		useAWG := fs.Bool("awg", false, "group by AWG size instead of metric size")
+		withWeight := fs.Bool("weight", false, "include conductor weight")
+		output := fs.String("o", "", "output file (default: standard output)")
+		if err := fs.Parse(flagArgs); err != nil {
+			return exitUsage
+		}
+		if *waste < 0 {
+			fmt.Println("Error: Cut-waste allowance must not be negative.")
+			return exitUsage
+		}
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		bom, err := buildBOM(p, *waste, *useAWG)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+
+		w := os.Stdout
+		if *output != "" {
+			w, err = os.Create(*output)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return exitError
+			}
+			defer w.Close()
+		}
+
+		switch *format {
+		case "csv":
+			err = writeBOMCSV(w, bom, *withWeight)
+		case "md", "markdown":
+			writeBOMMarkdown(w, bom, *withWeight, *waste)
+		default:
+			fmt.Printf("Error: Unknown BOM format %q.\n", *format)
+			return exitUsage
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		return exitOK
+
+	case "section":
+		if len(flagArgs) < 1 {
+			printProjectUsage()
+			return exitUsage
+		}
+		name := flagArgs[0]
+		circuits := fs.String("circuits", "", "comma-separated circuits routed through the section")
+		if err := fs.Parse(flagArgs[1:]); err != nil {
+			return exitUsage
+		}
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+
+		section := HarnessSection{Name: name}
+		for _, circuit := range strings.Split(*circuits, ",") {
+			circuit = strings.TrimSpace(circuit)
+			if circuit == "" {
+				continue
+			}
+			if p.findCircuit(circuit) == nil {
+				fmt.Printf("Error: circuit %q not found.\n", circuit)
+				return exitError
+			}
+			section.Circuits = append(section.Circuits, circuit)
+		}
+		if len(section.Circuits) == 0 {
+			fmt.Println("Error: A section needs at least one circuit.")
+			return exitUsage
+		}
+		if existing := p.findSection(name); existing != nil {
+			*existing = section
+		} else {
+			p.Sections = append(p.Sections, section)
+		}
+		if err := p.recalculate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if err := saveProject(path, p); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if err := printHarnessReport(p); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		return exitOK
+
+	case "harness":
+		if err := fs.Parse(flagArgs); err != nil {
+			return exitUsage
+		}
+		p, err := loadProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if err := printHarnessReport(p); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		return exitOK
+
+	default:
+		fmt.Printf("Error: Unknown project command %q.\n", command)
+		printProjectUsage()
+		return exitUsage
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testCircuit(name string) Circuit {
+	c := defaultCircuit()
+	c.Name = name
+	c.Voltage = 12.0
+	c.Current = 10.0
+	c.Length = 5.0
+	c.RoundTrip = true
+	return c
+}
+
+func TestCircuitRecalculate(t *testing.T) {
+	tests := []struct {
+		name           string
+		modify         func(*Circuit)
+		wantErr        bool
+		wantMetric     float64
+		wantConductors int
+	}{
+		{
+			name:       "12V 10A 5m round trip",
+			modify:     func(c *Circuit) {},
+			wantMetric: 6.0, // required 4.86 mm², the closest 4 mm² would be undersized
+		},
+		{
+			name:           "out of range uses parallel conductors",
+			modify:         func(c *Circuit) { c.Current = 200; c.Length = 30 },
+			wantMetric:     240.0,
+			wantConductors: 3,
+		},
+		{
+			name:       "automotive profile with ISO class",
+			modify:     func(c *Circuit) { c.Profile = "automotive"; c.WireType = "c"; c.Current = 1; c.Length = 2 },
+			wantMetric: 0.35,
+		},
+		{
+			name:    "unknown material",
+			modify:  func(c *Circuit) { c.Material = "gold" },
+			wantErr: true,
+		},
+		{
+			name:    "unknown installation",
+			modify:  func(c *Circuit) { c.Installation = "water" },
+			wantErr: true,
+		},
+		{
+			name:    "voltage above 50V",
+			modify:  func(c *Circuit) { c.Voltage = 60 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCircuit("test")
+			tt.modify(&c)
+			err := c.recalculate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("recalculate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := calculateCableArea(c.Voltage, c.Current, c.Length, c.MaxVoltageDropPercent, materials[c.Material], c.RoundTrip, c.AmbientTempCelsius, c.Installation)
+			if math.Abs(c.RequiredArea-want) > 0.0001 {
+				t.Errorf("RequiredArea = %v, want %v", c.RequiredArea, want)
+			}
+			if c.MetricSize != tt.wantMetric {
+				t.Errorf("MetricSize = %v, want %v", c.MetricSize, tt.wantMetric)
+			}
+			if c.Conductors != tt.wantConductors {
+				t.Errorf("Conductors = %v, want %v", c.Conductors, tt.wantConductors)
+			}
+			if c.AWGSize == "" {
+				t.Errorf("AWGSize should not be empty")
+			}
+		})
+	}
+}
+
+func TestProjectSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.json")
+
+	p := &Project{Name: "Van", Circuits: []Circuit{testCircuit("lights"), testCircuit("fridge")}}
+	p.Circuits[1].Components = []string{"crimp:2", "blade-fuse"}
+	if err := p.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	if err := saveProject(path, p); err != nil {
+		t.Fatalf("saveProject() error = %v", err)
+	}
+
+	loaded, err := loadProject(path)
+	if err != nil {
+		t.Fatalf("loadProject() error = %v", err)
+	}
+	if loaded.Name != p.Name || len(loaded.Circuits) != len(p.Circuits) {
+		t.Fatalf("loadProject() = %+v, want %+v", loaded, p)
+	}
+	for i := range p.Circuits {
+		if !reflect.DeepEqual(loaded.Circuits[i], p.Circuits[i]) {
+			t.Errorf("circuit %d = %+v, want %+v", i, loaded.Circuits[i], p.Circuits[i])
+		}
+	}
+
+	if loaded.findCircuit("fridge") == nil {
+		t.Errorf("findCircuit(fridge) = nil")
+	}
+	if loaded.findCircuit("heater") != nil {
+		t.Errorf("findCircuit(heater) should be nil")
+	}
+}
+
+func TestBindCircuitFlags(t *testing.T) {
+	c := testCircuit("lights")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	bindCircuitFlags(fs, &c)
+
+	if err := fs.Parse([]string{"-current", "15", "-length", "10ft", "-installation", "Conduit"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if c.Current != 15 {
+		t.Errorf("Current = %v, want 15", c.Current)
+	}
+	if math.Abs(c.Length-3.048) > 0.0001 {
+		t.Errorf("Length = %v, want 3.048", c.Length)
+	}
+	if c.Installation != InstallationConduit {
+		t.Errorf("Installation = %v, want %v", c.Installation, InstallationConduit)
+	}
+	// Inputs not given keep their previous values
+	if c.Voltage != 12 || !c.RoundTrip || c.Material != "copper" {
+		t.Errorf("unchanged inputs modified: %+v", c)
+	}
+
+	// -ground-temp does not overwrite -ambient, in either order
+	for _, args := range [][]string{{"-ambient", "35", "-ground-temp", "15"}, {"-ground-temp", "15", "-ambient", "35"}} {
+		c := testCircuit("pump")
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		bindCircuitFlags(fs, &c)
+		if err := fs.Parse(append(args, "-installation", "buried")); err != nil {
+			t.Fatalf("Parse(%v) error = %v", args, err)
+		}
+		if c.AmbientTempCelsius != 35 || c.GroundTempCelsius == nil || *c.GroundTempCelsius != 15 {
+			t.Errorf("%v: ambient %v, ground %v, want 35 and 15", args, c.AmbientTempCelsius, c.GroundTempCelsius)
+		}
+		if c.baseTemp() != 15 {
+			t.Errorf("%v: baseTemp() = %v, want 15", args, c.baseTemp())
+		}
+	}
+}
+
+func TestProjectRecalculateAmbient(t *testing.T) {
+	p := &Project{Name: "Van", Circuits: []Circuit{testCircuit("lights")}}
+	if err := p.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	before := p.Circuits[0].RequiredArea
+
+	p.Circuits[0].AmbientTempCelsius = 60
+	if err := p.recalculate(); err != nil {
+		t.Fatalf("recalculate() error = %v", err)
+	}
+	if p.Circuits[0].RequiredArea <= before {
+		t.Errorf("RequiredArea at 60°C = %v, should exceed %v at 20°C", p.Circuits[0].RequiredArea, before)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Prompt for a line of input and return it trimmed.
+func promptString(reader *bufio.Reader, prompt string) string {
+	fmt.Print(prompt)
+	line, _ := reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// Prompt for a number.
+//
+// An empty answer returns the given default value.
+func promptFloat(reader *bufio.Reader, prompt string, defaultValue float64) (float64, error) {
+	str := promptString(reader, prompt)
+	if str == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+// Prompt for a positive number without default.
+func promptPositiveFloat(reader *bufio.Reader, prompt string) (float64, error) {
+	value, err := strconv.ParseFloat(promptString(reader, prompt), 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("please enter a positive value")
+	}
+	return value, nil
+}
+
+// Prompt for a positive whole number.
+//
+// An empty answer returns the given default value.
+func promptCount(reader *bufio.Reader, prompt string, defaultValue int) (int, error) {
+	str := promptString(reader, prompt)
+	if str == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(str)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("please enter a positive whole number")
+	}
+	return value, nil
+}
+
+// Prompt for a cable material, falling back to copper.
+func promptMaterial(reader *bufio.Reader) CableMaterial {
+	materialStr := strings.ToLower(promptString(reader, "Cable material (copper/aluminum, default: copper): "))
+	material, ok := materials[materialStr]
+	if !ok {
+		material = materials["copper"]
+		fmt.Println("Using default: Copper")
+	}
+	return material
+}
+
+// Prompt for an installation method, falling back to in air.
+func promptInstallation(reader *bufio.Reader) InstallationMethod {
+	installStr := strings.ToLower(promptString(reader, "Installation method (air/conduit/isolated/buried/duct, default: air): "))
+	switch installStr {
+	case "conduit":
+		return InstallationConduit
+	case "isolated":
+		return InstallationIsolated
+	case "buried":
+		return InstallationBuried
+	case "duct":
+		return InstallationBuriedDuct
+	case "air", "":
+		return InstallationInAir
+	default:
+		fmt.Println("Using default: In air")
+		return InstallationInAir
+	}
+}
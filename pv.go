@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Solar PV sizing (off-grid systems)
+//
+// Sizes the three cable runs of a typical off-grid PV system in one run:
+// panel string to charge controller, charge controller to battery and
+// battery to inverter. Each leg is sized with calculateCableArea.
+
+const (
+	// Safety factor for PV short-circuit current at irradiance above
+	// standard test conditions (NEC 690.8(A))
+	pvIrradianceFactor = 1.25
+
+	// Safety factor for continuous current (NEC 690.8(B)),
+	// also applied to controller output and inverter input current
+	pvContinuousFactor = 1.25
+)
+
+// PVSystem describes an off-grid PV installation.
+//
+// Lengths are one-way distances; each leg is sized as a round trip
+// (positive and negative conductor).
+type PVSystem struct {
+	PanelIsc          float64 // Panel short-circuit current (A)
+	PanelVoc          float64 // Panel open-circuit voltage (V)
+	PanelVmp          float64 // Panel voltage at maximum power (V)
+	PanelsInSeries    int     // Panels per string
+	StringsInParallel int     // Number of parallel strings
+
+	ControllerMaxInputVoltage float64 // Maximum PV input voltage of the charge controller (V)
+	ControllerMaxCurrent      float64 // Maximum charge current of the controller (A)
+	BatteryVoltage            float64 // Nominal battery voltage (V)
+
+	InverterPower      float64 // Continuous inverter power (W), 0 if no inverter
+	InverterEfficiency float64 // Inverter efficiency (0-1)
+
+	PanelToControllerLength   float64 // m
+	ControllerToBatteryLength float64 // m
+	BatteryToInverterLength   float64 // m
+}
+
+// PVLeg is a single cable run of a PV system with its design current.
+type PVLeg struct {
+	Name    string
+	Voltage float64
+	Current float64
+	Length  float64
+}
+
+// PVLegResult holds the sizing result for a PV leg.
+type PVLegResult struct {
+	PVLeg
+	RequiredArea float64
+	MetricSize   float64
+	AWGLabel     string
+	AWGArea      float64
+	OutOfRange   *OutOfRangeResult
+}
+
+// Open-circuit voltage of a panel string.
+func (s PVSystem) StringVoc() float64 {
+	return s.PanelVoc * float64(s.PanelsInSeries)
+}
+
+// Maximum power point voltage of a panel string.
+func (s PVSystem) StringVmp() float64 {
+	return s.PanelVmp * float64(s.PanelsInSeries)
+}
+
+// Design current of the panel-to-controller leg.
+//
+// Formula: I = Isc × strings in parallel × 1.25 × 1.25
+func (s PVSystem) StringDesignCurrent() float64 {
+	return s.PanelIsc * float64(s.StringsInParallel) * pvIrradianceFactor * pvContinuousFactor
+}
+
+// Design current of the battery-to-inverter leg.
+//
+// Formula: I = P_inverter / (η × V_battery) × 1.25
+func (s PVSystem) InverterDesignCurrent() float64 {
+	return s.InverterPower / (s.InverterEfficiency * s.BatteryVoltage) * pvContinuousFactor
+}
+
+// Build the cable legs of a PV system.
+//
+// The panel string leg is sized at the string's maximum power point
+// voltage, where it operates. Returns an error if the string open-circuit
+// voltage exceeds the controller's maximum input voltage. The
+// battery-to-inverter leg is omitted without inverter.
+func pvLegs(s PVSystem) ([]PVLeg, error) {
+	if s.ControllerMaxInputVoltage > 0 && s.StringVoc() > s.ControllerMaxInputVoltage {
+		return nil, fmt.Errorf("string open-circuit voltage (%.1f V) exceeds controller maximum input voltage (%.1f V)", s.StringVoc(), s.ControllerMaxInputVoltage)
+	}
+
+	legs := []PVLeg{
+		{
+			Name:    "Panel to controller",
+			Voltage: s.StringVmp(),
+			Current: s.StringDesignCurrent(),
+			Length:  s.PanelToControllerLength,
+		},
+		{
+			Name:    "Controller to battery",
+			Voltage: s.BatteryVoltage,
+			Current: s.ControllerMaxCurrent * pvContinuousFactor,
+			Length:  s.ControllerToBatteryLength,
+		},
+	}
+
+	if s.InverterPower > 0 {
+		legs = append(legs, PVLeg{
+			Name:    "Battery to inverter",
+			Voltage: s.BatteryVoltage,
+			Current: s.InverterDesignCurrent(),
+			Length:  s.BatteryToInverterLength,
+		})
+	}
+
+	return legs, nil
+}
+
+// Size all legs of a PV system.
+func sizePVSystem(s PVSystem, maxVoltageDropPercent float64, material CableMaterial, ambientTempCelsius float64, installation InstallationMethod) ([]PVLegResult, error) {
+	legs, err := pvLegs(s)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PVLegResult, 0, len(legs))
+	for _, leg := range legs {
+		area := calculateCableArea(leg.Voltage, leg.Current, leg.Length, maxVoltageDropPercent, material, true, ambientTempCelsius, installation)
+		metric := findSmallestSizeIn(standardMetricSizes, area)
+		awgLabel, awgArea := findSmallestAWGIn(awgSizes, area)
+		results = append(results, PVLegResult{
+			PVLeg:        leg,
+			RequiredArea: area,
+			MetricSize:   metric,
+			AWGLabel:     awgLabel,
+			AWGArea:      awgArea,
+			OutOfRange:   checkOutOfRange(standardMetricSizes, area, leg.Voltage),
+		})
+	}
+
+	return results, nil
+}
+
+// Interactive PV sizing wizard.
+//
+// The command line only takes the diagnostic flags.
+func runPVWizard(reader *bufio.Reader, args []string) int {
+	fs := flag.NewFlagSet("pv", flag.ContinueOnError)
+	diagOpts := bindDiagnosticFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	var s PVSystem
+	var err error
+
+	fmt.Println("=== Solar PV Cable Sizing ===")
+	fmt.Println()
+
+	if s.PanelIsc, err = promptPositiveFloat(reader, "Panel short-circuit current Isc (A): "); err != nil {
+		fmt.Println("Error: Invalid Isc. Please enter a positive value.")
+		return exitError
+	}
+	if s.PanelVoc, err = promptPositiveFloat(reader, "Panel open-circuit voltage Voc (V): "); err != nil {
+		fmt.Println("Error: Invalid Voc. Please enter a positive value.")
+		return exitError
+	}
+	if s.PanelVmp, err = promptPositiveFloat(reader, "Panel maximum power voltage Vmp (V): "); err != nil || s.PanelVmp > s.PanelVoc {
+		fmt.Println("Error: Invalid Vmp. Please enter a positive value up to Voc.")
+		return exitError
+	}
+	if s.PanelsInSeries, err = promptCount(reader, "Panels in series per string (default: 1): ", 1); err != nil {
+		fmt.Println("Error: Invalid number of panels in series.")
+		return exitError
+	}
+	if s.StringsInParallel, err = promptCount(reader, "Strings in parallel (default: 1): ", 1); err != nil {
+		fmt.Println("Error: Invalid number of parallel strings.")
+		return exitError
+	}
+	if s.ControllerMaxInputVoltage, err = promptFloat(reader, "Controller maximum PV input voltage (V, empty to skip check): ", 0); err != nil || s.ControllerMaxInputVoltage < 0 {
+		fmt.Println("Error: Invalid controller input voltage.")
+		return exitError
+	}
+	if s.ControllerMaxCurrent, err = promptPositiveFloat(reader, "Controller maximum charge current (A): "); err != nil {
+		fmt.Println("Error: Invalid controller current. Please enter a positive value.")
+		return exitError
+	}
+	if s.BatteryVoltage, err = promptPositiveFloat(reader, "Battery voltage (V): "); err != nil {
+		fmt.Println("Error: Invalid battery voltage. Please enter a positive value.")
+		return exitError
+	}
+	if s.InverterPower, err = promptFloat(reader, "Inverter continuous power (W, empty for no inverter): ", 0); err != nil || s.InverterPower < 0 {
+		fmt.Println("Error: Invalid inverter power.")
+		return exitError
+	}
+	if s.InverterPower > 0 {
+		efficiency, err := promptFloat(reader, "Inverter efficiency (%, default 90%): ", 90)
+		if err != nil || efficiency <= 0 || efficiency > 100 {
+			fmt.Println("Error: Invalid inverter efficiency.")
+			return exitError
+		}
+		s.InverterEfficiency = efficiency / 100
+	}
+
+	if s.PanelToControllerLength, err = promptPositiveFloat(reader, "Panel to controller distance, one-way (m): "); err != nil {
+		fmt.Println("Error: Invalid length. Please enter a positive value.")
+		return exitError
+	}
+	if s.ControllerToBatteryLength, err = promptPositiveFloat(reader, "Controller to battery distance, one-way (m): "); err != nil {
+		fmt.Println("Error: Invalid length. Please enter a positive value.")
+		return exitError
+	}
+	if s.InverterPower > 0 {
+		if s.BatteryToInverterLength, err = promptPositiveFloat(reader, "Battery to inverter distance, one-way (m): "); err != nil {
+			fmt.Println("Error: Invalid length. Please enter a positive value.")
+			return exitError
+		}
+	}
+
+	maxVoltageDropPercent, err := promptFloat(reader, "Enter maximum voltage drop percentage (default 3%): ", 3.0)
+	if err != nil || maxVoltageDropPercent <= 0 || maxVoltageDropPercent > 10 {
+		fmt.Println("Warning: Invalid voltage drop percentage. Using default 3%.")
+		maxVoltageDropPercent = 3.0
+	}
+
+	material := promptMaterial(reader)
+
+	ambientTemp, err := promptFloat(reader, "Enter ambient temperature (°C, default 20): ", 20.0)
+	if err != nil {
+		fmt.Println("Error: Invalid temperature. Using default 20°C.")
+		ambientTemp = 20.0
+	}
+
+	installation := promptInstallation(reader)
+
+	results, err := sizePVSystem(s, maxVoltageDropPercent, material, ambientTemp, installation)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Println()
+	fmt.Println("=== PV System ===")
+	fmt.Printf("String: %d × %.2f V Voc = %.1f V open-circuit\n", s.PanelsInSeries, s.PanelVoc, s.StringVoc())
+	fmt.Printf("String: %d × %.2f V Vmp = %.1f V at maximum power\n", s.PanelsInSeries, s.PanelVmp, s.StringVmp())
+	fmt.Printf("Array: %d string(s) × %.2f A Isc × %.2f × %.2f = %.2f A design current\n", s.StringsInParallel, s.PanelIsc, pvIrradianceFactor, pvContinuousFactor, s.StringDesignCurrent())
+	fmt.Printf("Material: %s, Maximum Voltage Drop: %.2f%%\n", material.Name, maxVoltageDropPercent)
+	fmt.Println()
+
+	fmt.Println("=== Recommended Cable Sizes (round trip) ===")
+	var list []Diagnostic
+	for _, r := range results {
+		fmt.Printf("%s: %.1f V, %.2f A, %.2f m\n", r.Name, r.Voltage, r.Current, r.Length)
+		if r.OutOfRange != nil {
+			d := outOfRangeDiagnostic(r.OutOfRange)
+			d.Circuit = r.Name
+			list = append(list, *d)
+			printDiagnostics(os.Stdout, filterDiagnostics([]Diagnostic{*d}, diagOpts.Suppressed))
+			continue
+		}
+		fmt.Printf("  Required: %.2f mm², Metric: %.2f mm², AWG: %s (%.2f mm²)\n", r.RequiredArea, r.MetricSize, r.AWGLabel, r.AWGArea)
+	}
+
+	return diagOpts.reportExitCode(list)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func testPVSystem() PVSystem {
+	return PVSystem{
+		PanelIsc:                  10.0,
+		PanelVoc:                  22.0,
+		PanelVmp:                  18.0,
+		PanelsInSeries:            2,
+		StringsInParallel:         2,
+		ControllerMaxInputVoltage: 100.0,
+		ControllerMaxCurrent:      30.0,
+		BatteryVoltage:            12.0,
+		InverterPower:             1200.0,
+		InverterEfficiency:        0.9,
+		PanelToControllerLength:   10.0,
+		ControllerToBatteryLength: 2.0,
+		BatteryToInverterLength:   1.5,
+	}
+}
+
+func TestPVLegs(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*PVSystem)
+		wantErr     bool
+		wantLegs    int
+		wantVoltage []float64
+		wantCurrent []float64
+	}{
+		{
+			name:        "full system with inverter",
+			modify:      func(s *PVSystem) {},
+			wantLegs:    3,
+			wantVoltage: []float64{36.0, 12.0, 12.0},
+			// 10 × 2 × 1.25 × 1.25, 30 × 1.25, 1200 / (0.9 × 12) × 1.25
+			wantCurrent: []float64{31.25, 37.5, 138.8889},
+		},
+		{
+			name:        "no inverter",
+			modify:      func(s *PVSystem) { s.InverterPower = 0 },
+			wantLegs:    2,
+			wantVoltage: []float64{36.0, 12.0},
+			wantCurrent: []float64{31.25, 37.5},
+		},
+		{
+			// Voc 110 V exceeds the controller, although Vmp 90 V does not
+			name:    "string voltage exceeds controller",
+			modify:  func(s *PVSystem) { s.PanelsInSeries = 5 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testPVSystem()
+			tt.modify(&s)
+			legs, err := pvLegs(s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pvLegs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(legs) != tt.wantLegs {
+				t.Fatalf("pvLegs() returned %d legs, want %d", len(legs), tt.wantLegs)
+			}
+			for i, leg := range legs {
+				if math.Abs(leg.Voltage-tt.wantVoltage[i]) > 0.0001 {
+					t.Errorf("leg %s voltage = %v, want %v", leg.Name, leg.Voltage, tt.wantVoltage[i])
+				}
+				if math.Abs(leg.Current-tt.wantCurrent[i]) > 0.0001 {
+					t.Errorf("leg %s current = %v, want %v", leg.Name, leg.Current, tt.wantCurrent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSizePVSystem(t *testing.T) {
+	s := testPVSystem()
+	results, err := sizePVSystem(s, 3.0, materials["copper"], 20.0, InstallationInAir)
+	if err != nil {
+		t.Fatalf("sizePVSystem() error = %v", err)
+	}
+
+	for _, r := range results {
+		want := calculateCableArea(r.Voltage, r.Current, r.Length, 3.0, materials["copper"], true, 20.0, InstallationInAir)
+		if math.Abs(r.RequiredArea-want) > 0.0001 {
+			t.Errorf("%s required area = %v, want %v", r.Name, r.RequiredArea, want)
+		}
+		if r.MetricSize <= 0 || r.AWGLabel == "" {
+			t.Errorf("%s has no recommended size", r.Name)
+		}
+		// Never undersized, so the drop stays within 3%
+		if r.OutOfRange == nil && (r.MetricSize < r.RequiredArea || r.AWGArea < r.RequiredArea) {
+			t.Errorf("%s: %.2f mm² / AWG %s (%.2f mm²) for %.2f mm² required", r.Name, r.MetricSize, r.AWGLabel, r.AWGArea, r.RequiredArea)
+		}
+	}
+}
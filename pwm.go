@@ -0,0 +1,214 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+)
+
+// PWM and ripple currents
+//
+// Motor controllers and DC-DC converters draw pulsed currents: the on-state
+// current flows for a share D of each switching period, with a triangular
+// ripple ΔI (peak to peak) on top. The voltage drop is sized for the peak
+// current, the heating for the RMS current. At high switching frequencies
+// the AC part of the current crowds towards the conductor surface (skin
+// effect) and heats larger conductors more than their DC resistance
+// suggests.
+
+// Magnetic permeability of free space (H/m), for non-magnetic conductors
+const vacuumPermeability = 4 * math.Pi * 1e-7
+
+// PWMLoad is a pulsed current with a triangular ripple during the on-time.
+type PWMLoad struct {
+	Current   float64 // On-state current (A), the mean of the ripple
+	Duty      float64 // Share of the period switched on (0..1)
+	Frequency float64 // Switching frequency (Hz), 0 = no skin effect
+	Ripple    float64 // Ripple current, peak to peak (A)
+}
+
+// Peak current of a PWM load (A).
+//
+// Formula: I_peak = I_on + ΔI / 2
+func (l PWMLoad) Peak() float64 {
+	return l.Current + l.Ripple/2
+}
+
+// Average (DC) current of a PWM load (A).
+//
+// Formula: I_avg = D × I_on
+func (l PWMLoad) Average() float64 {
+	return l.Duty * l.Current
+}
+
+// RMS current of a PWM load (A).
+//
+// Formula: I_rms = √D × √(I_on² + ΔI² / 12)
+func (l PWMLoad) RMS() float64 {
+	return math.Sqrt(l.Duty) * math.Sqrt(l.Current*l.Current+l.Ripple*l.Ripple/12)
+}
+
+// RMS of the AC part of a PWM load (A).
+//
+// Formula: I_ac = √(I_rms² - I_avg²)
+func (l PWMLoad) ACRMS() float64 {
+	rms, avg := l.RMS(), l.Average()
+	return math.Sqrt(math.Max(rms*rms-avg*avg, 0))
+}
+
+// Calculate the skin depth of a conductor material.
+//
+// Formula: δ = √(ρ / (π × f × μ0))
+// Where:
+//   - ρ = resistivity at the conductor temperature (Ω·m)
+//   - f = frequency (Hz)
+//   - μ0 = 4π × 10⁻⁷ H/m
+//
+// Returns the skin depth in mm, or +Inf for DC.
+func skinDepth(material CableMaterial, tempCelsius, frequency float64) float64 {
+	if frequency <= 0 {
+		return math.Inf(1)
+	}
+	resistivity := calculateResistivityAtTemp(material, tempCelsius) * 1e-6
+	return math.Sqrt(resistivity/(math.Pi*frequency*vacuumPermeability)) * 1000
+}
+
+// Calculate the AC to DC resistance ratio of a round conductor.
+//
+// The current is assumed to flow in an outer ring of one skin depth:
+//
+//	Rac/Rdc = r² / (r² - (r - δ)²)    for r > δ, else 1
+//
+// This approaches r / (2δ) + 1/4 for thick conductors and errs on the high
+// side around r ≈ δ. Strands that are not insulated from each other (all
+// but litz wire) behave like a solid conductor of the same area.
+func acResistanceRatio(area, depth float64) float64 {
+	r := areaToDiameter(area) / 2
+	if r <= depth {
+		return 1
+	}
+	inner := r - depth
+	return r * r / (r*r - inner*inner)
+}
+
+// Calculate the DC current that heats a conductor like a PWM load.
+//
+// The DC part flows through Rdc, the AC part through Rac; the ripple
+// harmonics above the switching frequency are neglected.
+//
+// Formula: I_heat = √(I_avg² + Rac/Rdc × I_ac²)
+func (l PWMLoad) heatingCurrent(acRatio float64) float64 {
+	avg, ac := l.Average(), l.ACRMS()
+	return math.Sqrt(avg*avg + acRatio*ac*ac)
+}
+
+// PWMResult is the sizing of a circuit for a PWM load.
+type PWMResult struct {
+	Load           PWMLoad
+	Drop           Circuit // Circuit sized for the voltage drop at peak current
+	ThermalSize    float64 // Smallest size within the temperature rating (0 if none)
+	Size           float64 // Recommended size per conductor (mm²)
+	Conductors     int     // Parallel conductors of Size (0 = single)
+	SkinDepth      float64 // mm
+	ACRatio        float64 // Rac/Rdc of Size at the switching frequency
+	HeatingCurrent float64 // Equivalent DC heating current per conductor (A)
+	ConductorTemp  float64 // Estimated conductor temperature with Size (°C)
+}
+
+// Size a circuit for a PWM load.
+//
+// The voltage drop is sized for the peak current. For the heating every
+// size is checked with its own Rac/Rdc ratio, since the skin effect grows
+// with the conductor diameter. The recommended size is the larger of both.
+func sizeForPWM(c Circuit, l PWMLoad) (PWMResult, error) {
+	if l.Current <= 0 || l.Duty <= 0 || l.Duty > 1 || l.Frequency < 0 || l.Ripple < 0 {
+		return PWMResult{}, fmt.Errorf("PWM load needs a positive current, a duty cycle between 0 and 100%% and non-negative frequency and ripple")
+	}
+	result := PWMResult{Load: l}
+
+	result.Drop = c
+	result.Drop.Current = l.Peak()
+	if err := result.Drop.recalculate(); err != nil {
+		return result, err
+	}
+	material, wireType, profile, _ := c.resolve()
+	result.SkinDepth = skinDepth(material, c.effectiveTemp(), l.Frequency)
+
+	// Each parallel conductor carries an equal share of the current
+	share := l
+	if result.Drop.Conductors > 1 {
+		share.Current /= float64(result.Drop.Conductors)
+		share.Ripple /= float64(result.Drop.Conductors)
+	}
+	ambient := c.correctedAmbient()
+	heating := func(size float64) float64 {
+		return share.heatingCurrent(acResistanceRatio(size, result.SkinDepth))
+	}
+
+	result.Size = result.Drop.MetricSize
+	result.Conductors = result.Drop.Conductors
+	for _, size := range profile.MetricSizes {
+		if estimateConductorTemp(heating(size), size, material, ambient, c.Installation) <= wireType.MaxTempCelsius {
+			result.ThermalSize = size
+			result.Size = math.Max(result.Size, size)
+			break
+		}
+	}
+	result.ACRatio = acResistanceRatio(result.Size, result.SkinDepth)
+	result.HeatingCurrent = heating(result.Size)
+	result.ConductorTemp = estimateConductorTemp(result.HeatingCurrent, result.Size, material, ambient, c.Installation)
+	return result, nil
+}
+
+// Run the pwm command.
+func runPWMCommand(args []string) int {
+	fs := flag.NewFlagSet("pwm", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "pwm"
+	bindCircuitFlags(fs, &c)
+	duty := fs.Float64("duty", 100, "duty cycle (%); -current is the on-state current")
+	frequency := fs.Float64("frequency", 0, "switching frequency (Hz)")
+	ripple := fs.Float64("ripple", 0, "ripple current, peak to peak (A)")
+	diagOpts := bindDiagnosticFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *duty <= 0 || *duty > 100 {
+		fmt.Println("Error: Duty cycle must be between 0 and 100%.")
+		return exitUsage
+	}
+
+	load := PWMLoad{Current: c.Current, Duty: *duty / 100, Frequency: *frequency, Ripple: *ripple}
+	result, err := sizeForPWM(c, load)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	material, wireType, _, _ := c.resolve()
+
+	fmt.Println("=== PWM Current Sizing ===")
+	fmt.Printf("On-state current: %.2f A, duty cycle %.1f%%, ripple %.2f A p-p\n", load.Current, *duty, load.Ripple)
+	fmt.Printf("Peak current: %.2f A\n", load.Peak())
+	fmt.Printf("Average current: %.2f A\n", load.Average())
+	fmt.Printf("RMS current: %.2f A\n", load.RMS())
+	fmt.Println()
+	fmt.Printf("Voltage drop at peak current: %.2f mm² required, %s\n", result.Drop.RequiredArea, chosenSizeLabel(result.Drop))
+	if result.ThermalSize > 0 {
+		fmt.Printf("Heating: %.2f mm² (%s ≤ %.0f°C)\n", result.ThermalSize, wireType.Name, wireType.MaxTempCelsius)
+	} else {
+		fmt.Printf("Heating: no standard size stays within %.0f°C\n", wireType.MaxTempCelsius)
+	}
+
+	sized := result.Drop
+	sized.MetricSize = result.Size
+	fmt.Printf("Recommended size: %s\n", chosenSizeLabel(sized))
+	fmt.Printf("Voltage drop at peak current: %.2f%%\n", sized.voltageDrop(material, sized.chosenArea())/c.Voltage*100)
+	if load.Frequency > 0 {
+		fmt.Printf("Skin depth at %.0f Hz: %.2f mm (conductor Ø %.2f mm, Rac/Rdc %.2f)\n",
+			load.Frequency, result.SkinDepth, areaToDiameter(result.Size), result.ACRatio)
+	}
+	fmt.Printf("Heating current per conductor: %.2f A\n", result.HeatingCurrent)
+	fmt.Printf("Estimated conductor temperature: %.1f°C\n", result.ConductorTemp)
+
+	return diagOpts.report(collectDiagnostics(checkSkinEffect(result.ACRatio), checkWireTemperature(result.ConductorTemp, wireType)))
+}
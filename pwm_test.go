@@ -0,0 +1,104 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPWMLoadCurrents(t *testing.T) {
+	l := PWMLoad{Current: 100, Duty: 0.25, Ripple: 20}
+
+	if got := l.Peak(); got != 110 {
+		t.Errorf("peak: got %v, want 110", got)
+	}
+	if got := l.Average(); got != 25 {
+		t.Errorf("average: got %v, want 25", got)
+	}
+	// √0.25 × √(100² + 20²/12) ≈ 50.08 A
+	if got := l.RMS(); math.Abs(got-50.08) > 0.01 {
+		t.Errorf("RMS: got %.3f, want about 50.08", got)
+	}
+	if got, want := l.ACRMS(), math.Sqrt(l.RMS()*l.RMS()-25*25); math.Abs(got-want) > 1e-9 {
+		t.Errorf("AC RMS: got %v, want %v", got, want)
+	}
+
+	dc := PWMLoad{Current: 40, Duty: 1}
+	if dc.RMS() != 40 || dc.ACRMS() != 0 {
+		t.Errorf("pure DC: got RMS %v, AC %v, want 40, 0", dc.RMS(), dc.ACRMS())
+	}
+	if got := dc.heatingCurrent(3); got != 40 {
+		t.Errorf("pure DC heating current: got %v, want 40", got)
+	}
+}
+
+func TestSkinDepth(t *testing.T) {
+	copper := materials["copper"]
+
+	if got := skinDepth(copper, 20, 0); !math.IsInf(got, 1) {
+		t.Errorf("DC: got %v, want +Inf", got)
+	}
+	// Copper at 20 kHz: about 0.47 mm
+	if got := skinDepth(copper, 20, 20000); math.Abs(got-0.47) > 0.01 {
+		t.Errorf("copper at 20 kHz: got %.3f mm, want about 0.47 mm", got)
+	}
+	if hot, cold := skinDepth(copper, 90, 20000), skinDepth(copper, 20, 20000); hot <= cold {
+		t.Errorf("expected a larger skin depth when hot: %.3f <= %.3f mm", hot, cold)
+	}
+}
+
+func TestACResistanceRatio(t *testing.T) {
+	if got := acResistanceRatio(0.5, 0.47); got != 1 {
+		t.Errorf("thin conductor: got %v, want 1", got)
+	}
+	if got := acResistanceRatio(50, math.Inf(1)); got != 1 {
+		t.Errorf("DC: got %v, want 1", got)
+	}
+
+	// Thick conductors approach r / (2δ) + 1/4
+	r := areaToDiameter(240) / 2
+	if got, want := acResistanceRatio(240, 0.47), r/(2*0.47)+0.25; math.Abs(got-want)/want > 0.01 {
+		t.Errorf("240 mm²: got %.3f, want about %.3f", got, want)
+	}
+	if small, large := acResistanceRatio(10, 0.47), acResistanceRatio(95, 0.47); small >= large {
+		t.Errorf("expected the ratio to grow with size: %.2f >= %.2f", small, large)
+	}
+}
+
+func TestSizeForPWM(t *testing.T) {
+	c := defaultCircuit()
+	c.Name = "motor"
+	c.Voltage = 24
+	c.Current = 150
+	c.Length = 2
+	c.RoundTrip = true
+	c.WireType = "xlpe"
+
+	dc, err := sizeForPWM(c, PWMLoad{Current: 150, Duty: 0.6, Ripple: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc.Drop.Current != 165 {
+		t.Errorf("drop sized for %v A, want the 165 A peak", dc.Drop.Current)
+	}
+	if dc.ACRatio != 1 || math.Abs(dc.HeatingCurrent-dc.Load.RMS()) > 1e-9 {
+		t.Errorf("without frequency: got Rac/Rdc %v and heating %.2f A, want 1 and the RMS current", dc.ACRatio, dc.HeatingCurrent)
+	}
+	if dc.ConductorTemp > 90 {
+		t.Errorf("recommended size runs at %.1f°C, above the XLPE rating", dc.ConductorTemp)
+	}
+
+	hf, err := sizeForPWM(c, PWMLoad{Current: 150, Duty: 0.6, Frequency: 20000, Ripple: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hf.ACRatio <= 1 || hf.HeatingCurrent <= dc.HeatingCurrent {
+		t.Errorf("expected skin effect at 20 kHz: Rac/Rdc %.2f, heating %.2f A", hf.ACRatio, hf.HeatingCurrent)
+	}
+	if hf.Size < dc.Size {
+		t.Errorf("expected at least %v mm² with skin effect, got %v mm²", dc.Size, hf.Size)
+	}
+
+	if _, err := sizeForPWM(c, PWMLoad{Current: 150, Duty: 1.5}); err == nil {
+		t.Error("expected an error for a duty cycle above 100%")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// Sensitivity analysis
+//
+// Perturbs each input of calculateCableArea by a user-specified range and
+// shows which changes push the recommendation to a different standard size.
+
+// SensitivityRanges holds the perturbation of each input.
+type SensitivityRanges struct {
+	CurrentPercent float64 // ± % of the current
+	LengthPercent  float64 // ± % of the length
+	AmbientDelta   float64 // ± °C of the ambient temperature
+	Installations  bool    // Evaluate all other installation methods
+}
+
+// SensitivityCase is the recommendation for one perturbed input.
+type SensitivityCase struct {
+	Parameter string
+	Value     string
+	Circuit   Circuit // Perturbed circuit with recalculated sizes
+	Changed   bool    // Recommendation differs from the baseline
+}
+
+// Run a sensitivity analysis on a circuit.
+//
+// Returns the recalculated baseline circuit and one case per perturbation:
+// low and high value of current, length and ambient temperature, plus every
+// other installation method if enabled. Zero ranges are skipped.
+func sensitivityAnalysis(c Circuit, r SensitivityRanges) (Circuit, []SensitivityCase, error) {
+	baseline := c
+	if err := baseline.recalculate(); err != nil {
+		return Circuit{}, nil, err
+	}
+
+	var cases []SensitivityCase
+	add := func(parameter, value string, modify func(*Circuit)) error {
+		pc := baseline
+		modify(&pc)
+		if err := pc.recalculate(); err != nil {
+			return err
+		}
+		cases = append(cases, SensitivityCase{
+			Parameter: parameter,
+			Value:     value,
+			Circuit:   pc,
+			Changed:   pc.MetricSize != baseline.MetricSize || pc.Conductors != baseline.Conductors,
+		})
+		return nil
+	}
+
+	for _, sign := range []float64{-1, 1} {
+		if r.CurrentPercent > 0 {
+			current := baseline.Current * (1 + sign*r.CurrentPercent/100)
+			if current > 0 {
+				if err := add("Current", fmt.Sprintf("%+.0f%% (%.2f A)", sign*r.CurrentPercent, current), func(pc *Circuit) { pc.Current = current }); err != nil {
+					return Circuit{}, nil, err
+				}
+			}
+		}
+	}
+	for _, sign := range []float64{-1, 1} {
+		if r.LengthPercent > 0 {
+			length := baseline.Length * (1 + sign*r.LengthPercent/100)
+			if length > 0 {
+				if err := add("Length", fmt.Sprintf("%+.0f%% (%.2f m)", sign*r.LengthPercent, length), func(pc *Circuit) { pc.Length = length }); err != nil {
+					return Circuit{}, nil, err
+				}
+			}
+		}
+	}
+	for _, sign := range []float64{-1, 1} {
+		if r.AmbientDelta > 0 {
+			ambient := baseline.AmbientTempCelsius + sign*r.AmbientDelta
+			if err := add("Ambient", fmt.Sprintf("%+.1f°C (%.1f°C)", sign*r.AmbientDelta, ambient), func(pc *Circuit) { pc.AmbientTempCelsius = ambient }); err != nil {
+				return Circuit{}, nil, err
+			}
+		}
+	}
+	if r.Installations {
+		for _, installation := range []InstallationMethod{InstallationInAir, InstallationConduit, InstallationIsolated} {
+			if installation == baseline.Installation {
+				continue
+			}
+			if err := add("Installation", string(installation), func(pc *Circuit) { pc.Installation = installation }); err != nil {
+				return Circuit{}, nil, err
+			}
+		}
+	}
+
+	return baseline, cases, nil
+}
+
+// Describe the chosen metric size of a circuit.
+func chosenSizeLabel(c Circuit) string {
+	if c.Conductors > 1 {
+		return fmt.Sprintf("%d × %.2f mm²", c.Conductors, c.MetricSize)
+	}
+	return fmt.Sprintf("%.2f mm²", c.MetricSize)
+}
+
+// Run the sensitivity command.
+func runSensitivityCommand(args []string) int {
+	fs := flag.NewFlagSet("sensitivity", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "sensitivity"
+	bindCircuitFlags(fs, &c)
+	var r SensitivityRanges
+	fs.Float64Var(&r.CurrentPercent, "current-range", 20, "current variation (± %)")
+	fs.Float64Var(&r.LengthPercent, "length-range", 20, "length variation (± %)")
+	fs.Float64Var(&r.AmbientDelta, "ambient-range", 15, "ambient temperature variation (± °C)")
+	fs.BoolVar(&r.Installations, "installations", true, "evaluate all installation methods")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	baseline, cases, err := sensitivityAnalysis(c, r)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Println("=== Sensitivity Analysis ===")
+	fmt.Printf("Baseline: %.2f mm² required, recommended %s, AWG %s\n", baseline.RequiredArea, chosenSizeLabel(baseline), baseline.AWGSize)
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Parameter\tChange\tRequired\tMetric\tAWG\t")
+	var changed []string
+	for _, sc := range cases {
+		marker := ""
+		if sc.Changed {
+			marker = "← size changes"
+			changed = append(changed, sc.Parameter+" "+sc.Value)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.2f mm²\t%s\t%s\t%s\n", sc.Parameter, sc.Value, sc.Circuit.RequiredArea, chosenSizeLabel(sc.Circuit), sc.Circuit.AWGSize, marker)
+	}
+	w.Flush()
+
+	fmt.Println()
+	if len(changed) == 0 {
+		fmt.Println("The recommended metric size is stable over all given ranges.")
+	} else {
+		fmt.Println("Changes that alter the recommended metric size:")
+		for _, change := range changed {
+			fmt.Printf("  - %s\n", change)
+		}
+	}
+	return exitOK
+}
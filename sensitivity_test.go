@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestSensitivityAnalysis(t *testing.T) {
+	c := testCircuit("sensitivity")
+	c.Current = 20 // 9.72 mm² required, 10 mm² recommended
+
+	baseline, cases, err := sensitivityAnalysis(c, SensitivityRanges{
+		CurrentPercent: 20,
+		LengthPercent:  20,
+		AmbientDelta:   15,
+		Installations:  true,
+	})
+	if err != nil {
+		t.Fatalf("sensitivityAnalysis() error = %v", err)
+	}
+	if baseline.MetricSize != 10.0 {
+		t.Fatalf("baseline size = %v, want 10", baseline.MetricSize)
+	}
+
+	// 2 current + 2 length + 2 ambient + 2 other installation methods
+	if len(cases) != 8 {
+		t.Fatalf("got %d cases, want 8", len(cases))
+	}
+
+	wantChanged := map[string]bool{
+		"Current -20% (16.00 A)":  false, // 7.78 mm² still needs 10 mm²
+		"Current +20% (24.00 A)":  true,  // 11.67 mm² -> 16 mm²
+		"Length -20% (4.00 m)":    false,
+		"Length +20% (6.00 m)":    true,
+		"Ambient -15.0°C (5.0°C)": false,
+		"Installation isolated":   true, // 10.49 mm² -> 16 mm²
+	}
+	for _, sc := range cases {
+		key := sc.Parameter + " " + sc.Value
+		want, ok := wantChanged[key]
+		if !ok {
+			continue
+		}
+		if sc.Changed != want {
+			t.Errorf("%s changed = %v, want %v (size %v)", key, sc.Changed, want, sc.Circuit.MetricSize)
+		}
+	}
+
+	t.Run("zero ranges", func(t *testing.T) {
+		_, cases, err := sensitivityAnalysis(c, SensitivityRanges{})
+		if err != nil {
+			t.Fatalf("sensitivityAnalysis() error = %v", err)
+		}
+		if len(cases) != 0 {
+			t.Errorf("got %d cases, want none", len(cases))
+		}
+	})
+
+	t.Run("invalid circuit", func(t *testing.T) {
+		invalid := c
+		invalid.Current = 0
+		if _, _, err := sensitivityAnalysis(invalid, SensitivityRanges{CurrentPercent: 10}); err == nil {
+			t.Errorf("sensitivityAnalysis() should fail for invalid circuit")
+		}
+	})
+}
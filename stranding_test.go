@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBundleDiameter(t *testing.T) {
+	tests := []struct {
+		class int
+		want  float64
+	}{
+		{1, 3.568},
+		{2, 4.040}, // 3.568 / √0.78
+		{5, 4.205}, // 3.568 / √0.72
+		{6, 4.327}, // 3.568 / √0.68
+	}
+	for _, tt := range tests {
+		if got := bundleDiameter(10, strandingClasses[tt.class]); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("class %d: got %.3f mm, want %.3f mm", tt.class, got, tt.want)
+		}
+	}
+	if got := bundleDiameter(10, StrandingClass{}); got != areaToDiameter(10) {
+		t.Errorf("no class: got %v, want solid %v", got, areaToDiameter(10))
+	}
+}
+
+func TestStrandCount(t *testing.T) {
+	tests := []struct {
+		area  float64
+		class int
+		want  int
+	}{
+		{10, 1, 1},
+		{10, 2, 7},
+		{50, 2, 19},
+		{240, 2, 61},
+		{1.5, 5, 29},  // 1.5 / (π/4 × 0.26²) = 28.3
+		{10, 5, 76},   // 10 / (π/4 × 0.41²) = 75.7
+		{1.5, 6, 75},  // 1.5 / (π/4 × 0.16²) = 74.6
+		{95, 6, 1259}, // 95 / (π/4 × 0.31²) = 1258.7
+	}
+	for _, tt := range tests {
+		if got := strandCount(tt.area, tt.class); got != tt.want {
+			t.Errorf("strandCount(%v, %d) = %d, want %d", tt.area, tt.class, got, tt.want)
+		}
+	}
+}
+
+func TestLookupStrandingClass(t *testing.T) {
+	for _, class := range []int{1, 2, 5, 6} {
+		if sc, err := lookupStrandingClass(class); err != nil || sc.Class != class {
+			t.Errorf("lookupStrandingClass(%d) = %v, %v", class, sc, err)
+		}
+	}
+	if _, err := lookupStrandingClass(3); err == nil {
+		t.Error("expected error for class 3")
+	}
+
+	c := testCircuit("stranding")
+	c.Stranding = 4
+	if err := c.validate(); err == nil {
+		t.Error("expected validation error for class 4")
+	}
+}
+
+func TestWireTypeStranding(t *testing.T) {
+	for key, wireType := range wireTypes {
+		if _, err := lookupStrandingClass(wireType.Stranding); err != nil {
+			t.Errorf("wire type %s: %v", key, err)
+		}
+	}
+	for key, wireType := range iso6722TempClasses {
+		if _, err := lookupStrandingClass(wireType.Stranding); err != nil {
+			t.Errorf("ISO 6722 class %s: %v", key, err)
+		}
+	}
+}
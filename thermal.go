@@ -0,0 +1,51 @@
+package main
+
+import "math"
+
+// Heat transfer coefficients from the conductor surface to the ambient
+// (W/m²K), combined convection and radiation
+var installationHeatTransfer = map[InstallationMethod]float64{
+	InstallationInAir:      10.0, // Free air
+	InstallationConduit:    6.0,  // Enclosed in conduit
+	InstallationIsolated:   3.0,  // Surrounded by thermal insulation
+	InstallationBuried:     6.0,  // Soil at reference conditions
+	InstallationBuriedDuct: 4.0,  // Duct in soil at reference conditions
+}
+
+// Estimate the steady-state conductor temperature for a current.
+//
+// Heat balance per metre of conductor:
+//
+//	I² × ρ(T) / A = h × π × d × (T - T_ambient)
+//
+// With ρ(T) = ρ20 × (1 + α × (T - 20)) this solves to
+//
+//	k  = I² × ρ20 / (A × h × π × d)
+//	ΔT = k × (1 + α × (T_ambient - 20)) / (1 - k × α)
+//
+// Where A is in mm², d in m and h in W/m²K. Returns +Inf when the heating
+// grows faster than the cooling (thermal runaway). The insulation is
+// neglected, which errs on the hot side.
+func estimateConductorTemp(current, area float64, material CableMaterial, ambientTempCelsius float64, installation InstallationMethod) float64 {
+	h := installationHeatTransfer[installation]
+	d := areaToDiameter(area) / 1000
+	k := current * current * material.Resistivity20C / (area * h * math.Pi * d)
+	if k*material.TempCoefficient >= 1 {
+		return math.Inf(1)
+	}
+	rise := k * (1 + material.TempCoefficient*(ambientTempCelsius-referenceTemp)) / (1 - k*material.TempCoefficient)
+	return ambientTempCelsius + rise
+}
+
+// Find the smallest size whose estimated conductor temperature stays within
+// a maximum temperature.
+//
+// Sizes must be in ascending order. Returns false if no size is sufficient.
+func findThermalSize(sizes []float64, current float64, material CableMaterial, ambientTempCelsius float64, installation InstallationMethod, maxTempCelsius float64) (float64, bool) {
+	for _, size := range sizes {
+		if estimateConductorTemp(current, size, material, ambientTempCelsius, installation) <= maxTempCelsius {
+			return size, true
+		}
+	}
+	return 0, false
+}
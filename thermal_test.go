@@ -0,0 +1,47 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateConductorTemp(t *testing.T) {
+	copper := materials["copper"]
+
+	if got := estimateConductorTemp(0, 10, copper, 25, InstallationInAir); got != 25 {
+		t.Errorf("no current: got %v, want ambient 25", got)
+	}
+
+	// 20 A in 10 mm²: k = 400 × 0.0175 / (10 × 10 × π × 0.003568) ≈ 6.25 K
+	got := estimateConductorTemp(20, 10, copper, 20, InstallationInAir)
+	if math.Abs(got-26.4) > 0.1 {
+		t.Errorf("20 A in 10 mm²: got %.2f°C, want about 26.4°C", got)
+	}
+
+	air := estimateConductorTemp(60, 10, copper, 20, InstallationInAir)
+	conduit := estimateConductorTemp(60, 10, copper, 20, InstallationConduit)
+	isolated := estimateConductorTemp(60, 10, copper, 20, InstallationIsolated)
+	if !(air < conduit && conduit < isolated) {
+		t.Errorf("expected air < conduit < isolated, got %.1f, %.1f, %.1f", air, conduit, isolated)
+	}
+
+	if got := estimateConductorTemp(1000, 0.5, copper, 20, InstallationIsolated); !math.IsInf(got, 1) {
+		t.Errorf("thermal runaway: got %v, want +Inf", got)
+	}
+}
+
+func TestFindThermalSize(t *testing.T) {
+	copper := materials["copper"]
+
+	size, ok := findThermalSize(standardMetricSizes, 87.13, copper, 20, InstallationIsolated, 90)
+	if !ok || size != 50 {
+		t.Errorf("got %v, %v, want 50, true", size, ok)
+	}
+	if temp := estimateConductorTemp(87.13, size, copper, 20, InstallationIsolated); temp > 90 {
+		t.Errorf("temperature with %v mm² = %.1f°C, want ≤ 90°C", size, temp)
+	}
+
+	if _, ok := findThermalSize(standardMetricSizes, 5000, copper, 20, InstallationIsolated, 90); ok {
+		t.Error("expected no size for 5000 A")
+	}
+}
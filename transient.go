@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+)
+
+// Transient thermal simulation
+//
+// Lumped thermal mass model of one conductor: the heat capacity comes from
+// the conductor material, the cooling from the installation method. For
+// short high-current events this gives the peak conductor temperature over
+// time instead of a steady-state estimate.
+
+// Default simulation time step (s)
+const defaultThermalStep = 0.1
+
+// ThermalPoint is one point of a simulated temperature curve.
+type ThermalPoint struct {
+	Time    float64 // s
+	Current float64 // A
+	Temp    float64 // °C
+}
+
+// ThermalSimulation is the result of a transient thermal simulation.
+type ThermalSimulation struct {
+	Points     []ThermalPoint
+	PeakTemp   float64 // °C
+	ReachedMax bool
+	TimeToMax  float64 // s, time the maximum temperature was first reached
+}
+
+// Simulate the conductor temperature over a load profile.
+//
+// The profile is repeated for the given number of cycles, starting at the
+// ambient temperature. Per metre of conductor (explicit Euler steps):
+//
+//	C  = A × 10⁻⁶ × ρ_density × c                  (J/K per m)
+//	dT = (I² × ρ(T) / A - h × π × d × (T - T_ambient)) / C × dt
+//
+// Where A is in mm², d in m and h is the heat transfer coefficient of the
+// installation method.
+func simulateConductorTemp(p LoadProfile, cycles int, area float64, material CableMaterial, ambientTempCelsius float64, installation InstallationMethod, maxTempCelsius, step float64) (ThermalSimulation, error) {
+	if material.SpecificHeat <= 0 || material.Density <= 0 {
+		return ThermalSimulation{}, fmt.Errorf("material %s has no density or specific heat", material.Name)
+	}
+	if step <= 0 {
+		return ThermalSimulation{}, errors.New("time step must be positive")
+	}
+
+	capacity := conductorMass(area, 1.0, material) * material.SpecificHeat
+	cooling := installationHeatTransfer[installation] * math.Pi * areaToDiameter(area) / 1000
+
+	temp := ambientTempCelsius
+	sim := ThermalSimulation{
+		Points:   []ThermalPoint{{Time: 0, Temp: temp}},
+		PeakTemp: temp,
+	}
+	if len(p.Steps) > 0 {
+		sim.Points[0].Current = p.Steps[0].Current
+	}
+	var t float64
+	for cycle := 0; cycle < cycles; cycle++ {
+		for _, s := range p.Steps {
+			n := int(math.Ceil(s.Duration / step))
+			dt := s.Duration / float64(n)
+			for i := 0; i < n; i++ {
+				heating := s.Current * s.Current * calculateResistivityAtTemp(material, temp) / area
+				next := temp + (heating-cooling*(temp-ambientTempCelsius))/capacity*dt
+
+				if !sim.ReachedMax && next >= maxTempCelsius {
+					sim.ReachedMax = true
+					sim.TimeToMax = t + dt*(maxTempCelsius-temp)/(next-temp)
+				}
+				temp = next
+				t += dt
+				sim.PeakTemp = math.Max(sim.PeakTemp, temp)
+				sim.Points = append(sim.Points, ThermalPoint{Time: t, Current: s.Current, Temp: temp})
+			}
+		}
+	}
+	return sim, nil
+}
+
+// ThermalSizeResult is the simulated peak temperature of one conductor size.
+type ThermalSizeResult struct {
+	Size       float64 // mm²
+	PeakTemp   float64 // °C
+	ReachedMax bool
+	TimeToMax  float64 // s
+}
+
+// Simulate the conductor temperature over a load profile for every size.
+//
+// Takes the same parameters as simulateConductorTemp and keeps only the
+// peak temperature and the time to the maximum temperature of each size.
+func simulateSizes(p LoadProfile, cycles int, sizes []float64, material CableMaterial, ambientTempCelsius float64, installation InstallationMethod, maxTempCelsius, step float64) ([]ThermalSizeResult, error) {
+	results := make([]ThermalSizeResult, 0, len(sizes))
+	for _, size := range sizes {
+		sim, err := simulateConductorTemp(p, cycles, size, material, ambientTempCelsius, installation, maxTempCelsius, step)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, ThermalSizeResult{Size: size, PeakTemp: sim.PeakTemp, ReachedMax: sim.ReachedMax, TimeToMax: sim.TimeToMax})
+	}
+	return results, nil
+}
+
+// Write a simulated temperature curve as CSV.
+func writeThermalCSV(path string, points []ThermalPoint) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write([]string{"time_s", "current_a", "temp_c"})
+	for _, p := range points {
+		w.Write([]string{
+			strconv.FormatFloat(p.Time, 'f', 2, 64),
+			strconv.FormatFloat(p.Current, 'f', 2, 64),
+			strconv.FormatFloat(p.Temp, 'f', 2, 64),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Run the thermal command.
+func runThermalCommand(args []string) int {
+	fs := flag.NewFlagSet("thermal", flag.ContinueOnError)
+	c := defaultCircuit()
+	c.Name = "thermal"
+	bindCircuitFlags(fs, &c)
+	sizeStr := fs.String("size", "", "conductor size (mm² or AWG, default: recommended size)")
+	peak := fs.Float64("peak", 0, "peak current (A); -current is the continuous current")
+	duty := fs.Float64("duty", 10, "share of the period at peak current (%)")
+	period := fs.Float64("period", 60, "duty cycle period (s)")
+	loadFile := fs.String("load", "", "load profile CSV file (seconds,amps)")
+	duration := fs.Float64("duration", 600, "duration of a constant current (s)")
+	cycles := fs.Int("cycles", 1, "number of profile repetitions")
+	step := fs.Float64("step", defaultThermalStep, "simulation time step (s)")
+	interval := fs.Float64("interval", 0, "time between printed points (s, default: 20 points)")
+	output := fs.String("o", "", "write the full temperature curve to a CSV file")
+	diagOpts := bindDiagnosticFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *cycles <= 0 {
+		fmt.Println("Error: Number of cycles must be positive.")
+		return exitUsage
+	}
+
+	var p LoadProfile
+	switch {
+	case *loadFile != "":
+		var err error
+		if p, err = loadLoadProfile(*loadFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+	case *peak > 0:
+		if *duty < 0 || *duty > 100 || *period <= 0 {
+			fmt.Println("Error: Duty cycle must be between 0 and 100% and the period positive.")
+			return exitUsage
+		}
+		p = dutyCycleProfile(*peak, c.Current, *duty, *period)
+	default:
+		if *duration <= 0 {
+			fmt.Println("Error: Duration must be positive.")
+			return exitUsage
+		}
+		p = LoadProfile{Steps: []LoadStep{{Duration: *duration, Current: c.Current}}}
+	}
+
+	material, wireType, profile, err := c.resolve()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	var area float64
+	var label string
+	if *sizeStr != "" {
+		if area, label, err = parseConductorSize(*sizeStr); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitUsage
+		}
+	} else {
+		sized := c
+		sized.Current = p.Peak()
+		if err := sized.recalculate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		if sized.Conductors > 1 {
+			// Each parallel conductor carries an equal share of the current
+			for i := range p.Steps {
+				p.Steps[i].Current /= float64(sized.Conductors)
+			}
+		}
+		area = sized.MetricSize
+		label = chosenSizeLabel(sized)
+	}
+
+	sim, err := simulateConductorTemp(p, *cycles, area, material, c.correctedAmbient(), c.Installation, wireType.MaxTempCelsius, *step)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	sizes, err := simulateSizes(p, *cycles, profile.MetricSizes, material, c.correctedAmbient(), c.Installation, wireType.MaxTempCelsius, *step)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+
+	total := p.Duration() * float64(*cycles)
+	if *interval <= 0 {
+		*interval = total / 20
+	}
+
+	fmt.Println("=== Transient Conductor Temperature ===")
+	fmt.Printf("Conductor: %s %s, %s\n", label, material.Name, wireType.Name)
+	fmt.Printf("Ambient: %.1f°C, installation: %s\n", c.baseTemp(), c.Installation)
+	if rise := environmentTempRise(c.Installation, c.AltitudeMeters, c.SolarIrradiance); rise > 0 {
+		fmt.Printf("Altitude/solar correction: +%.1f K\n", rise)
+	}
+	if rise := soilTempRise(c.Installation, c.soilFactor()); rise != 0 {
+		fmt.Printf("Soil correction: %+.1f K\n", rise)
+	}
+	fmt.Println()
+	fmt.Printf("%12s %10s %14s\n", "Size", "Peak (°C)", "Time to max")
+	for _, r := range sizes {
+		timeToMax := "not reached"
+		if r.ReachedMax {
+			timeToMax = fmt.Sprintf("%.1f s", r.TimeToMax)
+		}
+		// Far beyond the rating the conductor would melt before the model's
+		// peak is reached
+		peakTemp := fmt.Sprintf("%.1f", r.PeakTemp)
+		if r.PeakTemp > 1000 {
+			peakTemp = "> 1000"
+		}
+		marker := ""
+		if math.Abs(r.Size-area) < 1e-9 {
+			marker = " <"
+		}
+		fmt.Printf("%8g mm² %10s %14s%s\n", r.Size, peakTemp, timeToMax, marker)
+	}
+	fmt.Println()
+	fmt.Printf("Temperature curve of %s:\n", label)
+	fmt.Printf("%10s %10s %10s\n", "Time (s)", "Current", "Temp (°C)")
+	next := 0.0
+	for i, pt := range sim.Points {
+		if pt.Time+1e-9 >= next || i == len(sim.Points)-1 {
+			fmt.Printf("%10.1f %8.1f A %10.1f\n", pt.Time, pt.Current, pt.Temp)
+			next += *interval
+			for next <= pt.Time {
+				next += *interval
+			}
+		}
+	}
+	fmt.Println()
+	fmt.Printf("Peak conductor temperature: %.1f°C\n", sim.PeakTemp)
+	list := collectDiagnostics(checkTransientTemp(sim, wireType))
+	if list == nil {
+		fmt.Printf("%s maximum rating (%.0f°C) not reached\n", wireType.Name, wireType.MaxTempCelsius)
+	}
+
+	if *output != "" {
+		if err := writeThermalCSV(*output, sim.Points); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitError
+		}
+		fmt.Printf("Temperature curve written to %s\n", *output)
+	}
+	return diagOpts.report(list)
+}
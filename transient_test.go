@@ -0,0 +1,107 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimulateConductorTemp(t *testing.T) {
+	copper := materials["copper"]
+
+	t.Run("approaches steady state", func(t *testing.T) {
+		p := LoadProfile{Steps: []LoadStep{{Duration: 3600, Current: 50}}}
+		sim, err := simulateConductorTemp(p, 1, 10, copper, 20, InstallationInAir, 90, 1)
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		want := estimateConductorTemp(50, 10, copper, 20, InstallationInAir)
+		final := sim.Points[len(sim.Points)-1].Temp
+		if math.Abs(final-want) > 0.5 {
+			t.Errorf("final temperature %.2f°C, want steady state %.2f°C", final, want)
+		}
+		if sim.ReachedMax {
+			t.Errorf("ReachedMax = true at %.1f°C", sim.PeakTemp)
+		}
+	})
+
+	t.Run("adiabatic start", func(t *testing.T) {
+		// Initial slope without cooling: dT/dt = I² × ρ20 / (A² × 10⁻⁶ × ρ_density × c)
+		p := LoadProfile{Steps: []LoadStep{{Duration: 1, Current: 150}}}
+		sim, err := simulateConductorTemp(p, 1, 6, copper, 20, InstallationInAir, 90, 0.01)
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		slope := 150.0 * 150 * copper.Resistivity20C / (6 * 6 * 1e-6 * copper.Density * copper.SpecificHeat)
+		if got := sim.PeakTemp - 20; math.Abs(got-slope)/slope > 0.02 {
+			t.Errorf("rise after 1 s = %.3f K, want about %.3f K", got, slope)
+		}
+	})
+
+	t.Run("time to max", func(t *testing.T) {
+		p := LoadProfile{Steps: []LoadStep{{Duration: 120, Current: 150}}}
+		sim, err := simulateConductorTemp(p, 1, 6, copper, 20, InstallationInAir, 90, defaultThermalStep)
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		if !sim.ReachedMax || sim.TimeToMax < 15 || sim.TimeToMax > 25 {
+			t.Errorf("ReachedMax = %v after %.1f s, want about 20 s", sim.ReachedMax, sim.TimeToMax)
+		}
+	})
+
+	t.Run("cycles cool down", func(t *testing.T) {
+		p := dutyCycleProfile(300, 0, 5, 60)
+		sim, err := simulateConductorTemp(p, 3, 25, copper, 20, InstallationInAir, 90, defaultThermalStep)
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		if got := sim.Points[len(sim.Points)-1].Time; math.Abs(got-180) > 1e-6 {
+			t.Errorf("end time = %v, want 180", got)
+		}
+		final := sim.Points[len(sim.Points)-1].Temp
+		if final >= sim.PeakTemp {
+			t.Errorf("final %.2f°C not below peak %.2f°C after the off time", final, sim.PeakTemp)
+		}
+	})
+
+	t.Run("missing specific heat", func(t *testing.T) {
+		silver := CableMaterial{Name: "Silver", Resistivity20C: 0.0159, TempCoefficient: 0.0038, Density: 10490}
+		p := LoadProfile{Steps: []LoadStep{{Duration: 1, Current: 10}}}
+		if _, err := simulateConductorTemp(p, 1, 1, silver, 20, InstallationInAir, 90, defaultThermalStep); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestSimulateSizes(t *testing.T) {
+	copper := materials["copper"]
+	p := LoadProfile{Steps: []LoadStep{{Duration: 120, Current: 150}}}
+	results, err := simulateSizes(p, 1, standardMetricSizes, copper, 20, InstallationInAir, 90, defaultThermalStep)
+	if err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	if len(results) != len(standardMetricSizes) {
+		t.Fatalf("got %d results, want %d", len(results), len(standardMetricSizes))
+	}
+
+	// Each size matches its own simulation
+	sim, _ := simulateConductorTemp(p, 1, 6, copper, 20, InstallationInAir, 90, defaultThermalStep)
+	for _, r := range results {
+		if r.Size == 6 && (r.PeakTemp != sim.PeakTemp || r.TimeToMax != sim.TimeToMax) {
+			t.Errorf("6 mm²: got %.1f°C after %.1f s, want %.1f°C after %.1f s", r.PeakTemp, r.TimeToMax, sim.PeakTemp, sim.TimeToMax)
+		}
+	}
+
+	// Larger sizes heat more slowly until the maximum is no longer reached
+	for i := 1; i < len(results); i++ {
+		prev, r := results[i-1], results[i]
+		if r.PeakTemp > prev.PeakTemp {
+			t.Errorf("%g mm² peaks at %.1f°C, above %g mm² at %.1f°C", r.Size, r.PeakTemp, prev.Size, prev.PeakTemp)
+		}
+		if r.ReachedMax && (!prev.ReachedMax || r.TimeToMax < prev.TimeToMax) {
+			t.Errorf("%g mm² reaches the maximum after %.1f s, before %g mm²", r.Size, r.TimeToMax, prev.Size)
+		}
+	}
+	if !results[0].ReachedMax || results[len(results)-1].ReachedMax {
+		t.Error("want the smallest size to reach the maximum and the largest not")
+	}
+}
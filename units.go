@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Unit handling
+//
+// All calculations are done in metric units (m, mm², °C). The functions in
+// this file convert user input in imperial units to metric and format
+// metric results for display in metric, imperial or both.
+
+const (
+	// Length of one foot (m)
+	metersPerFoot = 0.3048
+
+	// Length of one inch (mm)
+	mmPerInch = 25.4
+)
+
+// LengthUnit represents the unit of a length input.
+type LengthUnit string
+
+const (
+	LengthMeters LengthUnit = "m"
+	LengthFeet   LengthUnit = "ft"
+)
+
+// DisplayUnits selects the unit system used to display results.
+type DisplayUnits string
+
+const (
+	DisplayMetric   DisplayUnits = "metric"
+	DisplayImperial DisplayUnits = "imperial"
+	DisplayBoth     DisplayUnits = "both"
+)
+
+// Convert Fahrenheit to Celsius
+func fahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+// Convert Celsius to Fahrenheit
+func celsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+// Convert feet to meters
+func feetToMeters(ft float64) float64 {
+	return ft * metersPerFoot
+}
+
+// Convert meters to feet
+func metersToFeet(m float64) float64 {
+	return m / metersPerFoot
+}
+
+// Convert kcmil (thousands of circular mils) to mm²
+func kcmilToMm2(kcmil float64) float64 {
+	return kcmil * mm2PerKcmil
+}
+
+// Convert mm² to kcmil
+func mm2ToKcmil(mm2 float64) float64 {
+	return mm2 / mm2PerKcmil
+}
+
+// Convert circular mils to mm²
+func circularMilsToMm2(cmil float64) float64 {
+	return kcmilToMm2(cmil / 1000)
+}
+
+// Parse a length with optional unit suffix.
+//
+// Accepts e.g. "10", "10m", "32.8 ft", "32.8'" or "32.8feet". Values without
+// a suffix are interpreted in the given default unit. Returns meters.
+func parseLength(s string, defaultUnit LengthUnit) (float64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	unit := defaultUnit
+	for _, suffix := range []struct {
+		text string
+		unit LengthUnit
+	}{
+		{"feet", LengthFeet},
+		{"foot", LengthFeet},
+		{"ft", LengthFeet},
+		{"'", LengthFeet},
+		{"m", LengthMeters},
+	} {
+		if strings.HasSuffix(s, suffix.text) {
+			unit = suffix.unit
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix.text))
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid length %q", s)
+	}
+	if unit == LengthFeet {
+		return feetToMeters(value), nil
+	}
+	return value, nil
+}
+
+// Parse a conductor size given in mm², AWG, kcmil or circular mils.
+//
+// Accepted forms include "2.5", "2.5mm2", "2.5 mm²", "12 AWG", "#12",
+// "4/0", "250 kcmil", "250 MCM" and "500000 cmil". Plain numbers are mm².
+// Returns the area in mm² and a display label.
+func parseConductorSize(s string) (float64, string, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, "", fmt.Errorf("empty conductor size")
+	}
+
+	number := func(str string) (float64, error) {
+		value, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+		if err != nil || value <= 0 {
+			return 0, fmt.Errorf("invalid conductor size %q", s)
+		}
+		return value, nil
+	}
+
+	switch {
+	case strings.HasSuffix(s, "kcmil"), strings.HasSuffix(s, "mcm"):
+		value, err := number(strings.TrimSuffix(strings.TrimSuffix(s, "kcmil"), "mcm"))
+		if err != nil {
+			return 0, "", err
+		}
+		return kcmilToMm2(value), strconv.FormatFloat(value, 'f', -1, 64) + " kcmil", nil
+
+	case strings.HasSuffix(s, "cmil"):
+		value, err := number(strings.TrimSuffix(s, "cmil"))
+		if err != nil {
+			return 0, "", err
+		}
+		return circularMilsToMm2(value), strconv.FormatFloat(value, 'f', -1, 64) + " cmil", nil
+
+	case strings.HasSuffix(s, "mm²"), strings.HasSuffix(s, "mm2"):
+		value, err := number(strings.TrimSuffix(strings.TrimSuffix(s, "mm²"), "mm2"))
+		if err != nil {
+			return 0, "", err
+		}
+		return value, fmt.Sprintf("%.2f mm²", value), nil
+
+	case strings.HasSuffix(s, "awg"), strings.HasPrefix(s, "#"), strings.HasSuffix(s, "/0"):
+		gauge := strings.TrimSpace(strings.TrimPrefix(strings.TrimSuffix(s, "awg"), "#"))
+		area, err := awgLabelArea(gauge)
+		if err != nil {
+			return 0, "", err
+		}
+		return area, "AWG " + gauge, nil
+	}
+
+	value, err := number(s)
+	if err != nil {
+		return 0, "", err
+	}
+	return value, fmt.Sprintf("%.2f mm²", value), nil
+}
+
+// Area of an AWG label such as "12" or "4/0" (mm²).
+//
+// Gauges from 4/0 to 40 AWG are computed from the AWG formula.
+func awgLabelArea(label string) (float64, error) {
+	gauge := 0
+	if strings.HasSuffix(label, "/0") {
+		zeros, err := strconv.Atoi(strings.TrimSuffix(label, "/0"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid AWG size %q", label)
+		}
+		gauge = 1 - zeros
+	} else {
+		var err error
+		gauge, err = strconv.Atoi(label)
+		if err != nil {
+			return 0, fmt.Errorf("invalid AWG size %q", label)
+		}
+	}
+
+	if gauge < -3 || gauge > 40 {
+		return 0, fmt.Errorf("AWG size %q outside supported range (40 AWG to 4/0)", label)
+	}
+	return roundSignificant(awgGaugeArea(gauge), 4), nil
+}
+
+// Parse display units, falling back to metric.
+func parseDisplayUnits(s string) (DisplayUnits, bool) {
+	switch DisplayUnits(strings.TrimSpace(strings.ToLower(s))) {
+	case DisplayImperial:
+		return DisplayImperial, true
+	case DisplayBoth:
+		return DisplayBoth, true
+	case DisplayMetric, "":
+		return DisplayMetric, true
+	}
+	return DisplayMetric, false
+}
+
+// Format a length given in meters.
+func formatLength(m float64, units DisplayUnits) string {
+	metric := fmt.Sprintf("%.2f m", m)
+	imperial := fmt.Sprintf("%.2f ft", metersToFeet(m))
+	return formatDual(metric, imperial, units)
+}
+
+// Format a cross-sectional area given in mm².
+//
+// Imperial areas are shown in kcmil from 1 kcmil upwards, otherwise in
+// circular mils.
+func formatArea(mm2 float64, units DisplayUnits) string {
+	metric := fmt.Sprintf("%.2f mm²", mm2)
+	kcmil := mm2ToKcmil(mm2)
+	imperial := fmt.Sprintf("%.0f cmil", kcmil*1000)
+	if kcmil >= 1 {
+		imperial = fmt.Sprintf("%.1f kcmil", kcmil)
+	}
+	return formatDual(metric, imperial, units)
+}
+
+// Format a diameter given in mm.
+func formatDiameter(mm float64, units DisplayUnits) string {
+	metric := fmt.Sprintf("%.2f mm", mm)
+	imperial := fmt.Sprintf("%.3f in", mm/mmPerInch)
+	return formatDual(metric, imperial, units)
+}
+
+// Format a temperature given in °C.
+func formatTemp(c float64, units DisplayUnits) string {
+	metric := fmt.Sprintf("%.1f°C", c)
+	imperial := fmt.Sprintf("%.1f°F", celsiusToFahrenheit(c))
+	return formatDual(metric, imperial, units)
+}
+
+func formatDual(metric, imperial string, units DisplayUnits) string {
+	switch units {
+	case DisplayImperial:
+		return imperial
+	case DisplayBoth:
+		return metric + " (" + imperial + ")"
+	}
+	return metric
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		defaultUnit LengthUnit
+		want        float64
+		wantErr     bool
+	}{
+		{name: "plain meters", input: "10", defaultUnit: LengthMeters, want: 10.0},
+		{name: "meters suffix", input: "10m", defaultUnit: LengthFeet, want: 10.0},
+		{name: "feet suffix", input: "10 ft", defaultUnit: LengthMeters, want: 3.048},
+		{name: "feet apostrophe", input: "100'", defaultUnit: LengthMeters, want: 30.48},
+		{name: "plain with feet default", input: "10", defaultUnit: LengthFeet, want: 3.048},
+		{name: "invalid", input: "ten", defaultUnit: LengthMeters, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLength(tt.input, tt.defaultUnit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLength() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("parseLength() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConductorSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantArea  float64
+		wantLabel string
+		wantErr   bool
+	}{
+		{name: "plain mm²", input: "2.5", wantArea: 2.5, wantLabel: "2.50 mm²"},
+		{name: "mm2 suffix", input: "16mm2", wantArea: 16.0, wantLabel: "16.00 mm²"},
+		{name: "mm² suffix", input: "16 mm²", wantArea: 16.0, wantLabel: "16.00 mm²"},
+		{name: "AWG suffix", input: "12 AWG", wantArea: 3.309, wantLabel: "AWG 12"},
+		{name: "hash prefix", input: "#10", wantArea: 5.261, wantLabel: "AWG 10"},
+		{name: "aught size", input: "4/0", wantArea: 107.2, wantLabel: "AWG 4/0"},
+		{name: "kcmil", input: "250 kcmil", wantArea: 126.677, wantLabel: "250 kcmil"},
+		{name: "MCM", input: "500MCM", wantArea: 253.354, wantLabel: "500 kcmil"},
+		{name: "circular mils", input: "500000 cmil", wantArea: 253.354, wantLabel: "500000 cmil"},
+		{name: "AWG out of range", input: "50 AWG", wantErr: true},
+		{name: "invalid", input: "thick", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area, label, err := parseConductorSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseConductorSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if math.Abs(area-tt.wantArea) > 0.001 {
+				t.Errorf("parseConductorSize() area = %v, want %v", area, tt.wantArea)
+			}
+			if label != tt.wantLabel {
+				t.Errorf("parseConductorSize() label = %v, want %v", label, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestUnitConversion(t *testing.T) {
+	if got := metersToFeet(feetToMeters(42.0)); math.Abs(got-42.0) > 0.0001 {
+		t.Errorf("metersToFeet(feetToMeters(42)) = %v, want 42", got)
+	}
+	if got := mm2ToKcmil(kcmilToMm2(250.0)); math.Abs(got-250.0) > 0.0001 {
+		t.Errorf("mm2ToKcmil(kcmilToMm2(250)) = %v, want 250", got)
+	}
+	if got := circularMilsToMm2(1000.0); math.Abs(got-mm2PerKcmil) > 0.0001 {
+		t.Errorf("circularMilsToMm2(1000) = %v, want %v", got, mm2PerKcmil)
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "length metric", got: formatLength(10, DisplayMetric), want: "10.00 m"},
+		{name: "length imperial", got: formatLength(3.048, DisplayImperial), want: "10.00 ft"},
+		{name: "length both", got: formatLength(3.048, DisplayBoth), want: "3.05 m (10.00 ft)"},
+		{name: "area metric", got: formatArea(2.5, DisplayMetric), want: "2.50 mm²"},
+		{name: "area imperial small", got: formatArea(0.2, DisplayImperial), want: "395 cmil"},
+		{name: "area imperial large", got: formatArea(126.677, DisplayImperial), want: "250.0 kcmil"},
+		{name: "diameter imperial", got: formatDiameter(25.4, DisplayImperial), want: "1.000 in"},
+		{name: "temperature both", got: formatTemp(20, DisplayBoth), want: "20.0°C (68.0°F)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+// CableEstimate holds the conductor weight and cost of a cable.
+type CableEstimate struct {
+	MassPerMeter float64 // kg/m
+	TotalMass    float64 // kg
+	Cost         float64 // Currency units
+}
+
+// Estimate conductor weight and cost of a cable.
+//
+// The length is the total conductor length (m), i.e. including the return
+// conductor for round trip. Insulation is not included.
+//
+// Formula: cost = m × price per kg
+func estimateCable(area, length float64, material CableMaterial) CableEstimate {
+	total := conductorMass(area, length, material)
+	return CableEstimate{
+		MassPerMeter: conductorMass(area, 1.0, material),
+		TotalMass:    total,
+		Cost:         total * material.PricePerKg,
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateCable(t *testing.T) {
+	tests := []struct {
+		name             string
+		area             float64
+		length           float64
+		material         CableMaterial
+		wantMassPerMeter float64
+		wantTotalMass    float64
+		wantCost         float64
+	}{
+		{
+			name:             "10 m of 4 mm² copper",
+			area:             4.0,
+			length:           10.0,
+			material:         materials["copper"],
+			wantMassPerMeter: 0.03584, // 4e-6 × 8960
+			wantTotalMass:    0.3584,
+			wantCost:         0.3584 * copperPricePerKg,
+		},
+		{
+			name:             "10 m of 6 mm² aluminum",
+			area:             6.0,
+			length:           10.0,
+			material:         materials["aluminum"],
+			wantMassPerMeter: 0.0162, // 6e-6 × 2700
+			wantTotalMass:    0.162,
+			wantCost:         0.162 * aluminumPricePerKg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimateCable(tt.area, tt.length, tt.material)
+			if math.Abs(got.MassPerMeter-tt.wantMassPerMeter) > 1e-9 {
+				t.Errorf("MassPerMeter = %v, want %v", got.MassPerMeter, tt.wantMassPerMeter)
+			}
+			if math.Abs(got.TotalMass-tt.wantTotalMass) > 1e-9 {
+				t.Errorf("TotalMass = %v, want %v", got.TotalMass, tt.wantTotalMass)
+			}
+			if math.Abs(got.Cost-tt.wantCost) > 1e-9 {
+				t.Errorf("Cost = %v, want %v", got.Cost, tt.wantCost)
+			}
+		})
+	}
+}